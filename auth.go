@@ -0,0 +1,265 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ключ в Principals, означающий все спорты
+const AllSports = "*"
+
+type AuthConfig struct {
+	Enabled bool `json:"enabled"`
+	// секрет HMAC-SHA256 подписи токенов; пустой - токены не принимаются, только API ключи
+	TokenSecret string                     `json:"token_secret"`
+	Principals  map[string]PrincipalConfig `json:"principals"` // ключ - имя клиента (партнера)
+}
+
+type PrincipalConfig struct {
+	APIKeys []string `json:"api_keys"`
+	Sports  []string `json:"sports"` // спорты, на которые клиент может подписываться, "*" - все
+}
+
+// principal - аутентифицированный клиент
+type principal struct {
+	name   string
+	sports map[string]struct{}
+	all    bool
+}
+
+// Entitled сообщает, доступны ли клиенту линии спорта; nil - аутентификация выключена, доступно все
+func (p *principal) Entitled(sportName string) bool {
+	if p == nil || p.all {
+		return true
+	}
+	_, found := p.sports[sportName]
+	return found
+}
+
+var (
+	errNoCredentials      = errors.New("No API key or token provided")
+	errInvalidCredentials = errors.New("Invalid API key or token")
+)
+
+// authenticator проверяет API ключи (заголовок x-api-key) и подписанные токены (Authorization: Bearer <token>)
+type authenticator struct {
+	secret     []byte
+	principals map[string]*principal
+	byAPIKey   map[string]*principal
+}
+
+func newAuthenticator(cfg AuthConfig) *authenticator {
+	a := &authenticator{
+		secret:     []byte(cfg.TokenSecret),
+		principals: make(map[string]*principal),
+		byAPIKey:   make(map[string]*principal),
+	}
+
+	for name, pc := range cfg.Principals {
+		p := &principal{name: name, sports: make(map[string]struct{})}
+		for _, sportName := range pc.Sports {
+			if sportName == AllSports {
+				p.all = true
+			}
+			p.sports[sportName] = struct{}{}
+		}
+
+		a.principals[name] = p
+		for _, key := range pc.APIKeys {
+			a.byAPIKey[key] = p
+		}
+	}
+
+	return a
+}
+
+// authenticate ищет клиента по значениям заголовков x-api-key и authorization
+func (a *authenticator) authenticate(apiKey, authorization string) (*principal, error) {
+	if apiKey != "" {
+		if p, found := a.byAPIKey[apiKey]; found {
+			return p, nil
+		}
+		return nil, errInvalidCredentials
+	}
+
+	if token := strings.TrimPrefix(authorization, "Bearer "); token != authorization && token != "" {
+		return a.verifyToken(token)
+	}
+
+	return nil, errNoCredentials
+}
+
+type tokenClaims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp,omitempty"` // unix time; 0 - бессрочный
+}
+
+// IssueToken выдает токен клиенту name, ttl 0 - бессрочный.
+// Токен - это base64url(JSON с claims) + "." + base64url(HMAC-SHA256 первой части)
+func (a *authenticator) IssueToken(name string, ttl time.Duration) (string, error) {
+	if len(a.secret) == 0 {
+		return "", errors.New("A token secret must be provided to issue tokens")
+	}
+	if _, found := a.principals[name]; !found {
+		return "", errors.New("Unknown principal: " + name)
+	}
+
+	claims := tokenClaims{Subject: name}
+	if ttl != 0 {
+		claims.ExpiresAt = time.Now().Add(ttl).Unix()
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(a.sign(encoded)), nil
+}
+
+func (a *authenticator) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+func (a *authenticator) verifyToken(token string) (*principal, error) {
+	if len(a.secret) == 0 {
+		return nil, errInvalidCredentials
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, errInvalidCredentials
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, a.sign(parts[0])) {
+		return nil, errInvalidCredentials
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errInvalidCredentials
+	}
+
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, errInvalidCredentials
+	}
+
+	if claims.ExpiresAt != 0 && time.Now().Unix() >= claims.ExpiresAt {
+		return nil, errors.New("The token has expired")
+	}
+
+	p, found := a.principals[claims.Subject]
+	if !found {
+		return nil, errInvalidCredentials
+	}
+	return p, nil
+}
+
+type principalKey struct{}
+
+// principalFromContext возвращает клиента запроса, nil - аутентификация выключена
+func principalFromContext(ctx context.Context) *principal {
+	p, _ := ctx.Value(principalKey{}).(*principal)
+	return p
+}
+
+// principalName возвращает имя клиента запроса, пустое - аутентификация выключена
+func principalName(ctx context.Context) string {
+	if p := principalFromContext(ctx); p != nil {
+		return p.name
+	}
+	return ""
+}
+
+// методы, доступные без аутентификации (проверки состояния сервиса)
+func authExempt(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/")
+}
+
+func (a *authenticator) authenticateGRPC(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) != 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	p, err := a.authenticate(first("x-api-key"), first("authorization"))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return context.WithValue(ctx, principalKey{}, p), nil
+}
+
+func (a *authenticator) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if authExempt(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	ctx, err := a.authenticateGRPC(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if authExempt(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	ctx, err := a.authenticateGRPC(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream - стрим с клиентом в контексте
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// Middleware аутентифицирует HTTP запросы, кроме перечисленных в exempt путей
+func (a *authenticator) Middleware(exempt ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, path := range exempt {
+				if r.URL.Path == path {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			p, err := a.authenticate(r.Header.Get("X-Api-Key"), r.Header.Get("Authorization"))
+			if err != nil {
+				RenderJSON(w, nil, http.StatusUnauthorized, err.Error())
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, p)))
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestAuthenticator(secret string) *authenticator {
+	return newAuthenticator(AuthConfig{
+		Enabled:     true,
+		TokenSecret: secret,
+		Principals: map[string]PrincipalConfig{
+			"partner": {APIKeys: []string{"partner-key"}, Sports: []string{"soccer"}},
+			"admin":   {APIKeys: []string{"admin-key"}, Sports: []string{AllSports}},
+		},
+	})
+}
+
+func mustIssueToken(t *testing.T, a *authenticator, name string, ttl time.Duration) string {
+	t.Helper()
+	token, err := a.IssueToken(name, ttl)
+	if err != nil {
+		t.Fatalf("Failed to issue a token: %v", err)
+	}
+	return token
+}
+
+func TestAuthenticate(t *testing.T) {
+	a := newTestAuthenticator("secret")
+
+	// тот же секрет, но клиент, которого нет в конфиге проверяющего
+	ghost := newAuthenticator(AuthConfig{TokenSecret: "secret", Principals: map[string]PrincipalConfig{"ghost": {}}})
+
+	valid := mustIssueToken(t, a, "partner", time.Hour)
+	parts := strings.Split(valid, ".")
+	otherPayload := strings.Split(mustIssueToken(t, a, "admin", time.Hour), ".")[0]
+
+	tests := []struct {
+		name          string
+		apiKey        string
+		authorization string
+		principal     string // пустое - аутентификация должна завершиться ошибкой
+	}{
+		{"API key", "partner-key", "", "partner"},
+		{"unknown API key", "stolen-key", "", ""},
+		{"no credentials", "", "", ""},
+		{"token", "", "Bearer " + valid, "partner"},
+		{"token without expiration", "", "Bearer " + mustIssueToken(t, a, "admin", 0), "admin"},
+		{"token without Bearer", "", valid, ""},
+		{"token signed with another secret", "", "Bearer " + mustIssueToken(t, newTestAuthenticator("other"), "partner", time.Hour), ""},
+		{"tampered payload", "", "Bearer " + otherPayload + "." + parts[1], ""},
+		{"no signature", "", "Bearer " + parts[0], ""},
+		{"malformed signature", "", "Bearer " + parts[0] + ".!!!", ""},
+		{"expired token", "", "Bearer " + mustIssueToken(t, a, "partner", -time.Second), ""},
+		{"unknown subject", "", "Bearer " + mustIssueToken(t, ghost, "ghost", time.Hour), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := a.authenticate(tt.apiKey, tt.authorization)
+			if tt.principal == "" {
+				if err == nil {
+					t.Fatalf("Expected an error, authenticated as %v", p.name)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if p.name != tt.principal {
+				t.Fatalf("Expected %v, authenticated as %v", tt.principal, p.name)
+			}
+		})
+	}
+}
+
+func TestIssueToken(t *testing.T) {
+	if _, err := newTestAuthenticator("").IssueToken("partner", 0); err == nil {
+		t.Fatal("Issued a token without a secret")
+	}
+	if _, err := newTestAuthenticator("secret").IssueToken("ghost", 0); err == nil {
+		t.Fatal("Issued a token to an unknown principal")
+	}
+
+	// без секрета токены не принимаются, даже подписанные пустым ключом
+	a := newTestAuthenticator("")
+	a.secret = []byte("secret")
+	token := mustIssueToken(t, a, "partner", 0)
+	if _, err := newTestAuthenticator("").authenticate("", "Bearer "+token); err == nil {
+		t.Fatal("Accepted a token without a secret")
+	}
+}
+
+func TestEntitled(t *testing.T) {
+	a := newTestAuthenticator("secret")
+	partner, admin := a.principals["partner"], a.principals["admin"]
+
+	tests := []struct {
+		name      string
+		principal *principal
+		sport     string
+		entitled  bool
+	}{
+		{"entitled sport", partner, "soccer", true},
+		{"not entitled sport", partner, "baseball", false},
+		{"wildcard", admin, "baseball", true},
+		{"wildcard and sports added later", admin, "hockey", true},
+		{"authentication disabled", nil, "baseball", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if entitled := tt.principal.Entitled(tt.sport); entitled != tt.entitled {
+				t.Fatalf("Expected %v, got %v", tt.entitled, entitled)
+			}
+		})
+	}
+}
+
+func TestValidateSportNameHidesNotEntitledSports(t *testing.T) {
+	s := newTestServer(t, "baseball", "football", "soccer")
+	a := newTestAuthenticator("secret")
+	ctx := context.WithValue(context.Background(), principalKey{}, a.principals["partner"])
+
+	err := s.validateSportName(ctx, "sport_name", "curling")
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument, got %v", err)
+	}
+
+	msg := status.Convert(err).Message()
+	if !strings.Contains(msg, "soccer") || strings.Contains(msg, "baseball") || strings.Contains(msg, "football") {
+		t.Fatalf("The error must list only the entitled sports: %v", msg)
+	}
+
+	// существующий, но недоступный спорт неотличим от несуществующего
+	if notEntitled := s.validateSportName(ctx, "sport_name", "baseball"); notEntitled == nil || notEntitled.Error() != err.Error() {
+		t.Fatalf("Expected the same error as for an unknown sport, got %v", notEntitled)
+	}
+	if err := s.validateSportName(ctx, "sport_name", "soccer"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
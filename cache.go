@@ -0,0 +1,63 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/softpro-junior-assignment/services"
+)
+
+// linesCache хранит последнюю линию каждого спорта, его обновляют воркеры после записи линий в хранилище,
+// а стримы читают из него; в хранилище за линией идут только если ее еще нет в кэше (холодный старт)
+type linesCache struct {
+	lines services.LinesDB
+
+	mu     sync.RWMutex
+	latest map[string]services.Line
+}
+
+func newLinesCache(lines services.LinesDB) *linesCache {
+	return &linesCache{
+		lines:  lines,
+		latest: make(map[string]services.Line),
+	}
+}
+
+// Set обновляет линию спорта, если она получена не раньше той, что уже в кэше
+func (c *linesCache) Set(sportName string, line services.Line) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, found := c.latest[sportName]; found && line.FetchedAt.Before(cached.FetchedAt) {
+		return
+	}
+	c.latest[sportName] = line
+}
+
+func (c *linesCache) Latest(sportName string) (services.Line, error) {
+	c.mu.RLock()
+	line, found := c.latest[sportName]
+	c.mu.RUnlock()
+	if found {
+		return line, nil
+	}
+
+	latest, err := c.lines.Latest(sportName)
+	if err == services.ErrNoLines {
+		return services.Line{}, noLinesError{sportName: sportName}
+	}
+	if err != nil {
+		return services.Line{}, err
+	}
+	c.Set(sportName, *latest)
+
+	return *latest, nil
+}
+
+// noLinesError - линий спорта еще нет ни в кэше, ни в хранилище: это не сбой хранилища, а еще не опрошенный спорт
+type noLinesError struct {
+	sportName string
+}
+
+func (e noLinesError) Error() string {
+	return "No lines of the " + e.sportName + " sport have been fetched yet"
+}
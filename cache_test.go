@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/softpro-junior-assignment/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeLinesDB - хранилище линий, в котором Latest возвращает заданную линию или ошибку
+type fakeLinesDB struct {
+	services.LinesDB
+	latest *services.Line
+	err    error
+}
+
+func (db fakeLinesDB) Latest(sportName string) (*services.Line, error) {
+	return db.latest, db.err
+}
+
+func TestLinesCacheLatest(t *testing.T) {
+	stored := services.Line{Line: 2.5, FetchedAt: time.Now()}
+
+	tests := []struct {
+		name string
+		db   fakeLinesDB
+		code codes.Code
+	}{
+		{"stored line", fakeLinesDB{latest: &stored}, codes.OK},
+		{"no lines yet", fakeLinesDB{err: services.ErrNoLines}, codes.FailedPrecondition},
+		{"storage failure", fakeLinesDB{err: errors.New("connection refused")}, codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, err := newLinesCache(tt.db).Latest("hockey")
+			if tt.code == codes.OK {
+				if err != nil || line.Line != stored.Line {
+					t.Fatalf("Expected %v, got %v, %v", stored.Line, line.Line, err)
+				}
+				return
+			}
+
+			if code := status.Code(latestLineError(err)); code != tt.code {
+				t.Fatalf("Expected %v, got %v (%v)", tt.code, code, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+type TLSConfig struct {
+	CertFile     string `json:"cert_file"` // пустой - без TLS
+	KeyFile      string `json:"key_file"`
+	ClientCAFile string `json:"client_ca_file"` // если задан, то клиенты должны предъявлять подписанный им сертификат (mTLS)
+	// как часто в секундах проверять, не изменились ли файлы; 0 - перечитывать их только по SIGHUP
+	ReloadInterval uint `json:"reload_interval"`
+}
+
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// certReloader отдает серверам текущие сертификат и CA клиентов, их можно перечитать с диска без перезапуска сервиса:
+// уже установленные соединения продолжают работать со старыми, а новые получают новые
+type certReloader struct {
+	cfg TLSConfig
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  map[string]time.Time
+}
+
+func newCertReloader(cfg TLSConfig) (*certReloader, error) {
+	r := &certReloader{cfg: cfg}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *certReloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+	return files
+}
+
+// Reload перечитывает файлы, при ошибке остаются прежние сертификат и CA
+func (r *certReloader) Reload() error {
+	modTimes := make(map[string]time.Time)
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTimes[file] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return err
+	}
+
+	var clientCAs *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		pem, err := ioutil.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return err
+		}
+
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return errors.New("No certificates found in the client CA file " + r.cfg.ClientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+
+	return nil
+}
+
+func (r *certReloader) changed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			// файл может временно отсутствовать, пока его заменяют
+			continue
+		}
+		if !info.ModTime().Equal(r.modTimes[file]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Watch перечитывает файлы по сигналу из hup и, если задан reload_interval, при изменении их mtime, пока не закрыт abort
+func (r *certReloader) Watch(hup <-chan os.Signal, abort <-chan struct{}) {
+	// nil канал, если файлы не проверяются периодически
+	var ticks <-chan time.Time
+	if r.cfg.ReloadInterval != 0 {
+		ticker := time.NewTicker(time.Duration(r.cfg.ReloadInterval) * time.Second)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	for {
+		select {
+		case <-abort:
+			return
+		case <-hup:
+		case <-ticks:
+			if !r.changed() {
+				continue
+			}
+		}
+
+		if err := r.Reload(); err != nil {
+			log.Printf("Failed to reload the TLS certificates: %v\n", err)
+			continue
+		}
+		log.Println("Reloaded the TLS certificates")
+	}
+}
+
+// TLSConfig возвращает конфиг для сервера, протоколы которого (ALPN) перечислены в nextProtos
+func (r *certReloader) TLSConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+		// нужен только для того, чтобы http.Server.ServeTLS не требовал файлы сертификата
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return r.cert, nil
+		},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			c := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   nextProtos,
+				Certificates: []tls.Certificate{*r.cert},
+			}
+			if r.clientCAs != nil {
+				c.ClientAuth = tls.RequireAndVerifyClientCert
+				c.ClientCAs = r.clientCAs
+			}
+			return c, nil
+		},
+	}
+}
@@ -1,24 +1,51 @@
 package main
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"flag"
 	"github.com/softpro-junior-assignment/pb"
+	pbv2 "github.com/softpro-junior-assignment/pb/v2"
 	"io"
+	"io/ioutil"
 	"log"
 	"time"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // должен совпадать с адресом сервера
 const addr = "192.168.99.100:9001"
 
 func main() {
-	option := flag.Int("o", 1, "Command to run")
+	option := flag.Int("o", 1, "Command to run: 1 - SubscribeOnSportsLines, 2 - GetLatestLines, 3 - ListSports and GetLatestLines (API v2)")
+	address := flag.String("addr", addr, "The server's address")
+	caFile := flag.String("ca", "", "A PEM file with the CA that signed the server's certificate, enables TLS")
+	certFile := flag.String("cert", "", "A PEM file with the client's certificate (mTLS)")
+	keyFile := flag.String("key", "", "A PEM file with the client's key (mTLS)")
+	serverName := flag.String("server-name", "", "Overrides the server name the server's certificate is verified against")
+	apiKey := flag.String("api-key", "", "An API key to authenticate with")
+	token := flag.String("token", "", "A signed token to authenticate with")
 	flag.Parse()
 
-	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	transport := grpc.WithInsecure()
+	if *caFile != "" {
+		creds, err := clientTLS(*caFile, *certFile, *keyFile, *serverName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		transport = grpc.WithTransportCredentials(creds)
+	}
+
+	options := []grpc.DialOption{transport}
+	if *apiKey != "" || *token != "" {
+		options = append(options, grpc.WithPerRPCCredentials(credentialsMetadata{apiKey: *apiKey, token: *token}))
+	}
+
+	conn, err := grpc.Dial(*address, options...)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -29,7 +56,52 @@ func main() {
 	switch *option {
 	case 1:
 		SubscribeOnSportsLines(client)
+	case 2:
+		GetLatestLines(client)
+	case 3:
+		GetLatestLinesV2(pbv2.NewSportsLinesServiceClient(conn))
+	}
+}
+
+// credentialsMetadata добавляет API ключ или токен в metadata каждого запроса
+type credentialsMetadata struct {
+	apiKey string
+	token  string
+}
+
+func (c credentialsMetadata) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	if c.apiKey != "" {
+		return map[string]string{"x-api-key": c.apiKey}, nil
+	}
+	return map[string]string{"authorization": "Bearer " + c.token}, nil
+}
+
+// без TLS ключ передается открытым текстом, но тестовому клиенту это позволено
+func (c credentialsMetadata) RequireTransportSecurity() bool {
+	return false
+}
+
+func clientTLS(caFile, certFile, keyFile, serverName string) (credentials.TransportCredentials, error) {
+	pem, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
 	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no certificates found in " + caFile)
+	}
+
+	c := &tls.Config{RootCAs: roots, ServerName: serverName}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		c.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(c), nil
 }
 
 func SubscribeOnSportsLines(client pb.SportsLinesServiceClient) {
@@ -60,7 +132,7 @@ func SubscribeOnSportsLines(client pb.SportsLinesServiceClient) {
 			}
 
 			if res != nil {
-				log.Println(res.SportInfos)
+				log.Println(res.Seq, res.SportInfos)
 			}
 		}
 	}()
@@ -107,3 +179,35 @@ func SubscribeOnSportsLines(client pb.SportsLinesServiceClient) {
 	stream.CloseSend()
 	<-doneCh
 }
+
+func GetLatestLines(client pb.SportsLinesServiceClient) {
+	log.SetFlags(log.Ltime)
+
+	res, err := client.GetLatestLines(context.Background(), &pb.GetLatestLinesRequest{
+		SportNames: []string{"baseball", "football", "soccer"},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Println(res.SportInfos)
+}
+
+func GetLatestLinesV2(client pbv2.SportsLinesServiceClient) {
+	log.SetFlags(log.Ltime)
+
+	sports, err := client.ListSports(context.Background(), &pbv2.ListSportsRequest{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println(sports.Sports)
+
+	res, err := client.GetLatestLines(context.Background(), &pbv2.GetLatestLinesRequest{
+		Sports: []pbv2.Sport{pbv2.Sport_BASEBALL, pbv2.Sport_FOOTBALL, pbv2.Sport_SOCCER},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Println(res.Lines)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/softpro-junior-assignment/services"
+)
+
+// runCommand выполняет команду вида "migrate up|down|status" или "token <principal> [ttl]" и возвращает код завершения процесса
+func runCommand(cfg Config, args []string) int {
+	if len(args) >= 2 && len(args) <= 3 && args[0] == "token" {
+		return issueToken(cfg, args[1:])
+	}
+
+	if len(args) != 2 || args[0] != "migrate" {
+		log.Printf("Unknown command: %v, expected 'migrate up|down|status' or 'token <principal> [ttl]'\n", args)
+		return 2
+	}
+
+	s, err := services.NewServices(
+		services.WithGorm(cfg.Database.Dialect(), cfg.Database.ConnectionInfo(), int(cfg.StorageConnNumOfAttempts), int(cfg.StorageConnIntervalBWAttempts)),
+		services.WithLogMode(cfg.Logmode),
+	)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	defer s.Close()
+
+	switch args[1] {
+	case "up":
+		err = s.MigrateUp()
+	case "down":
+		err = s.MigrateDown()
+	case "status":
+		var statuses []services.MigrationStatus
+		statuses, err = s.MigrationsStatus()
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = "applied at " + status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Printf("%4d %-30v %v\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		log.Printf("Unknown migrate command: %v, expected one of the following: up, down, status\n", args[1])
+		return 2
+	}
+
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	return 0
+}
+
+// issueToken выводит подписанный токен клиента args[0], действующий args[1] секунд (по умолчанию бессрочный)
+func issueToken(cfg Config, args []string) int {
+	var ttl time.Duration
+	if len(args) == 2 {
+		seconds, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			log.Printf("Invalid token TTL: %v, expected a number of seconds\n", args[1])
+			return 2
+		}
+		ttl = time.Duration(seconds) * time.Second
+	}
+
+	token, err := newAuthenticator(cfg.Auth).IssueToken(args[0], ttl)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+
+	fmt.Println(token)
+	return 0
+}
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"strings"
+
+	"github.com/softpro-junior-assignment/services"
 )
 
 type PostgresConfig struct {
@@ -44,15 +47,41 @@ type Config struct {
 	HTTPIP                        string          `json:"http_ip"`
 	GRPCPort                      uint            `json:"grpc_port"`
 	GRPCIP                        string          `json:"grpc_ip"`
+	GRPCKeepaliveTime             uint            `json:"grpc_keepalive_time"`
+	GRPCKeepaliveTimeout          uint            `json:"grpc_keepalive_timeout"`
+	GRPCKeepaliveMinTime          uint            `json:"grpc_keepalive_min_time"` // не чаще скольки секунд клиентам можно пинговать сервер
 	LinesProviderPort             uint            `json:"lines_provider_port"`
 	LinesProviderIP               string          `json:"lines_provider_ip"`
+	LinesProviderType             string          `json:"lines_provider_type"` // http, fake или file
+	LinesProviderFile             string          `json:"lines_provider_file"`
+	LinesProviderTimeout          uint            `json:"lines_provider_timeout"`
 	Logmode                       bool            `json:"log_mode"`
 	FirstSyncNumOfAttempts        uint            `json:"first_sync_num_of_attempts"`
 	FirstSyncIntervalBWAttempts   uint            `json:"first_sync_interval_bw_attempts"`
 	StorageConnNumOfAttempts      uint            `json:"storage_conn_num_of_attempts"`
 	StorageConnIntervalBWAttempts uint            `json:"storage_conn_interval_bw_attempts"`
+	WorkerBackoffInitial          uint            `json:"worker_backoff_initial"`
+	WorkerBackoffMax              uint            `json:"worker_backoff_max"`
+	WorkerMaxRestarts             uint            `json:"worker_max_restarts"` // 0 - перезапускать бесконечно
+	ShutdownTimeout               uint            `json:"shutdown_timeout"`
+	HealthCheckInterval           uint            `json:"health_check_interval"`
+	ReadinessStalenessFactor      uint            `json:"readiness_staleness_factor"`
+	HistoryMaxPoints              uint            `json:"history_max_points"`
+	SubscriptionResumeTTL         uint            `json:"subscription_resume_ttl"`
+	LineMin                       float64         `json:"line_min"` // допустимый диапазон линий от LinesProvider'а
+	LineMax                       float64         `json:"line_max"`
+	SportsSource                  string          `json:"sports_source"` // config или database
+	SportsReloadInterval          uint            `json:"sports_reload_interval"`
 	Intervals                     map[string]uint `json:"intervals"`
 	Database                      PostgresConfig  `json:"database"`
+
+	RetentionInterval uint                       `json:"retention_interval"`
+	Retention         map[string]RetentionConfig `json:"retention"` // ключ - имя спорта или "*" для остальных спортов
+
+	SubscriptionLimits SubscriptionLimitsConfig `json:"subscription_limits"`
+
+	TLS  TLSConfig  `json:"tls"` // общий для gRPC и HTTP серверов
+	Auth AuthConfig `json:"auth"`
 }
 
 func DefaultConfig() Config {
@@ -61,13 +90,35 @@ func DefaultConfig() Config {
 		HTTPIP:                        "",
 		GRPCPort:                      9001,
 		GRPCIP:                        "",
+		GRPCKeepaliveTime:             30,
+		GRPCKeepaliveTimeout:          10,
+		GRPCKeepaliveMinTime:          10,
 		LinesProviderPort:             8000,
 		LinesProviderIP:               "localhost",
+		LinesProviderType:             HTTPLinesProvider,
+		LinesProviderFile:             "",
+		LinesProviderTimeout:          5,
 		Logmode:                       false,
 		FirstSyncNumOfAttempts:        3,
 		FirstSyncIntervalBWAttempts:   1,
 		StorageConnNumOfAttempts:      3,
 		StorageConnIntervalBWAttempts: 3,
+		WorkerBackoffInitial:          1,
+		WorkerBackoffMax:              60,
+		WorkerMaxRestarts:             0,
+		ShutdownTimeout:               10,
+		HealthCheckInterval:           5,
+		ReadinessStalenessFactor:      3,
+		HistoryMaxPoints:              1000,
+		SubscriptionResumeTTL:         300,
+		LineMin:                       0,
+		LineMax:                       1000,
+		RetentionInterval:             3600,
+		Retention:                     map[string]RetentionConfig{},
+		SubscriptionLimits:            DefaultSubscriptionLimitsConfig(),
+		TLS:                           TLSConfig{ReloadInterval: 60},
+		SportsSource:                  ConfigSportsSource,
+		SportsReloadInterval:          60,
 		Intervals: map[string]uint{
 			"baseball": 1,
 			"football": 1,
@@ -95,20 +146,30 @@ func LoadConfig(configReq bool) Config {
 	}()
 
 	var c Config
+	// политика хранения необязательна, без нее в .config задача хранения запускается с интервалом по умолчанию
+	c.RetentionInterval = DefaultConfig().RetentionInterval
 	dec := json.NewDecoder(f)
 	err = dec.Decode(&c)
 	if err != nil {
 		panic(err)
 	}
 
-	if len(c.Intervals) != len(AvailableSportNames) {
-		log.Fatal("Not all the intervals were provided or count of provided intervals is more than count of available sport names")
+	switch c.SportsSource {
+	case ConfigSportsSource, "":
+		if len(c.Intervals) == 0 {
+			log.Fatal("Intervals must be provided with the 'config' sports source")
+		}
+	case DatabaseSportsSource:
+		if c.SportsReloadInterval == 0 {
+			log.Fatal("A sports reload interval can't be 0 with the 'database' sports source")
+		}
+	default:
+		log.Fatal("A sports source must be one of the following: " + strings.Join([]string{ConfigSportsSource, DatabaseSportsSource}, ", "))
 	}
 
 	for name, interval := range c.Intervals {
-		_, found := AvailableSportNames[name]
-		if !found {
-			log.Fatal("A sport name must be one of the following: " + strings.Join(AvailableSportNames.GetKeys(), ", "))
+		if !services.ValidSportName(name) {
+			log.Fatal(services.ErrInvalidSportName.Error() + ", got: " + name)
 		}
 
 		if interval == 0 {
@@ -122,8 +183,24 @@ func LoadConfig(configReq bool) Config {
 	if c.GRPCPort == 0 {
 		log.Fatal("gRPC port can't be 0")
 	}
-	if c.LinesProviderPort == 0 {
-		log.Fatal("LinesProvider's HTTP port can't be 0")
+	if c.GRPCKeepaliveTime == 0 || c.GRPCKeepaliveTimeout == 0 {
+		log.Fatal("gRPC keepalive time and timeout can't be 0")
+	}
+	switch c.LinesProviderType {
+	case HTTPLinesProvider, "":
+		if c.LinesProviderPort == 0 {
+			log.Fatal("LinesProvider's HTTP port can't be 0")
+		}
+		if c.LinesProviderTimeout == 0 {
+			log.Fatal("LinesProvider's request timeout can't be 0")
+		}
+	case FakeLinesProvider:
+	case FileLinesProvider:
+		if c.LinesProviderFile == "" {
+			log.Fatal("A lines provider file must be provided for the 'file' lines provider type")
+		}
+	default:
+		log.Fatal("A lines provider type must be one of the following: " + strings.Join([]string{HTTPLinesProvider, FakeLinesProvider, FileLinesProvider}, ", "))
 	}
 	if c.FirstSyncNumOfAttempts == 0 {
 		log.Fatal("A number of attempts can't be 0 (Lines Provider reconnection parameter)")
@@ -138,6 +215,79 @@ func LoadConfig(configReq bool) Config {
 		log.Fatal("An interval between attempts can't be 0 (Storage reconnection parameter)")
 	}
 
+	if c.WorkerBackoffInitial == 0 {
+		log.Fatal("An initial backoff can't be 0 (workers' restart parameter)")
+	}
+	if c.WorkerBackoffMax < c.WorkerBackoffInitial {
+		log.Fatal("A max backoff can't be less than the initial one (workers' restart parameter)")
+	}
+
+	if c.ShutdownTimeout == 0 {
+		log.Fatal("A shutdown timeout can't be 0")
+	}
+	if c.HealthCheckInterval == 0 {
+		log.Fatal("A health check interval can't be 0")
+	}
+	if c.ReadinessStalenessFactor == 0 {
+		log.Fatal("A readiness staleness factor can't be 0")
+	}
+	if c.HistoryMaxPoints == 0 {
+		log.Fatal("A max number of points in the lines' history can't be 0")
+	}
+	if math.IsNaN(c.LineMin) || math.IsNaN(c.LineMax) || c.LineMin >= c.LineMax {
+		log.Fatal("line_min must be less than line_max")
+	}
+	// линии хранятся и передаются как float32
+	if c.LineMin < -math.MaxFloat32 || c.LineMax > math.MaxFloat32 {
+		log.Fatal("line_min and line_max must be within the float32 range")
+	}
+	if c.RetentionInterval == 0 {
+		log.Fatal("A retention interval can't be 0")
+	}
+	for name, policy := range c.Retention {
+		if name != DefaultRetentionKey && !services.ValidSportName(name) {
+			log.Fatal("A retention policy's key must be a sport name or \"" + DefaultRetentionKey + "\", got: " + name)
+		}
+		if policy.KeepDays != 0 && policy.KeepDays <= policy.RawDays {
+			log.Fatal("A retention policy's keep_days must be greater than raw_days (sport: " + name + ")")
+		}
+	}
+
+	if c.SubscriptionResumeTTL == 0 {
+		log.Fatal("A subscription resume TTL can't be 0")
+	}
+	if c.SubscriptionLimits.MinInterval == 0 {
+		log.Fatal("A subscription's min interval can't be 0")
+	}
+	if c.SubscriptionLimits.MaxInterval < c.SubscriptionLimits.MinInterval {
+		log.Fatal("A subscription's max interval can't be less than the min one")
+	}
+
+	if c.TLS.Enabled() != (c.TLS.KeyFile != "") {
+		log.Fatal("Both a TLS certificate and a key must be provided")
+	}
+	if c.TLS.ClientCAFile != "" && !c.TLS.Enabled() {
+		log.Fatal("A client CA can't be used without a TLS certificate and a key")
+	}
+
+	if c.Auth.Enabled && len(c.Auth.Principals) == 0 {
+		log.Fatal("At least one principal must be provided when the authentication is enabled")
+	}
+	apiKeys := make(map[string]bool)
+	for name, principal := range c.Auth.Principals {
+		for _, key := range principal.APIKeys {
+			if key == "" || apiKeys[key] {
+				log.Fatal("API keys must be non-empty and unique (principal: " + name + ")")
+			}
+			apiKeys[key] = true
+		}
+		for _, sportName := range principal.Sports {
+			if sportName != AllSports && !services.ValidSportName(sportName) {
+				log.Fatal(services.ErrInvalidSportName.Error() + ", got: " + sportName + " (principal: " + name + ")")
+			}
+		}
+	}
+
 	fmt.Println("Successfully loaded .config")
 	return c
 }
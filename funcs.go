@@ -2,10 +2,9 @@ package main
 
 import (
 	"encoding/json"
-	"errors"
-	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/postgres"
 	_ "github.com/lib/pq"
+	"github.com/softpro-junior-assignment/services"
 	"log"
 	"net/http"
 	"sync"
@@ -29,91 +28,80 @@ func must(err error) {
 	}
 }
 
-func getLine(interval uint, db *gorm.DB, sportName, linesProviderAddr string, abort <-chan struct{}, n *sync.WaitGroup) {
-	defer n.Done()
-	// todo писал на этот счет в getFirstLine
-	firstPartOfQuery := `INSERT  INTO "` + sportName + `s" ("line") VALUES (`
-	addr := linesProviderAddr + sportName
-
-	for {
-		select {
-		case <-abort:
-			return
-		default:
-			resp, err := http.Get(addr)
-			if err != nil {
-				log.Println(err)
-				return
-			}
-			defer resp.Body.Close()
-
-			if resp.StatusCode != http.StatusOK {
-				log.Println("Status code isn't OK 200 (from LinesProvider, sport name: " + sportName + ")")
-				return
-			}
+// ingester опрашивает LinesProvider и записывает прошедшие проверку линии в хранилище
+type ingester struct {
+	lines    services.LinesDB
+	hub      *hub
+	provider LinesProvider
+	lineMin  float64
+	lineMax  float64
+	updates  *updateTracker
+}
 
-			dst := ParsedJSON{}
-			err = json.NewDecoder(resp.Body).Decode(&dst)
-			if err != nil {
-				log.Println(err)
-				return
-			}
+func (in *ingester) poll(sportName string) error {
+	start := time.Now()
+	dst, err := in.provider.GetLines(sportName)
+	providerRequestDuration.WithLabelValues(sportName).Observe(time.Since(start).Seconds())
+	if err != nil {
+		providerErrors.WithLabelValues(sportName, "request").Inc()
+		return err
+	}
+	fetchedAt := time.Now()
 
-			for _, line := range dst.Lines {
-				// хотя бы частичная параметризация
-				err = db.Exec(firstPartOfQuery+`?)`, line).Error
-				if err != nil {
-					log.Println(err)
-					return
-				}
-			}
+	lines, err := parseLines(sportName, dst, in.lineMin, in.lineMax)
+	if err != nil {
+		providerErrors.WithLabelValues(sportName, "payload").Inc()
+		return err
+	}
 
-			time.Sleep(time.Duration(interval) * time.Second)
-		}
+	poll := services.Poll{
+		Lines:        lines,
+		FetchedAt:    fetchedAt,
+		ProviderTime: dst.Time,
+		Source:       in.provider.Source(),
+	}
+	if err := in.lines.Insert(sportName, poll); err != nil {
+		return err
 	}
+	rowsInserted.WithLabelValues(sportName).Add(float64(len(lines)))
+	in.updates.Set(sportName, fetchedAt)
+
+	in.hub.Publish(sportName, services.Line{
+		Line:         float32(lines[len(lines)-1]),
+		FetchedAt:    poll.FetchedAt,
+		ProviderTime: poll.ProviderTime,
+		Source:       poll.Source,
+	})
+	return nil
 }
 
-func getFirstLine(db *gorm.DB, sportName, linesProviderAddr string, e chan<- error, n *sync.WaitGroup) {
-	defer n.Done()
+// getLine опрашивает LinesProvider, пока не закрыт abort; interval возвращает текущий интервал опроса спорта в секундах
+func getLine(interval func() uint, in *ingester, sportName string, abort <-chan struct{}, polled func()) error {
+	for {
+		if err := in.poll(sportName); err != nil {
+			return err
+		}
 
-	// todo знаю, что это sql инъекция, но пробовал по разному делать, и чз gorm api и чз database/sql. Проблема в том, что
-	//  с плейсхолдерами ($1, ($1), ?, (?)) не хочет работать запрос, если подставлять имена таблиц.
-	//  Как временное решение от sql инъекции здесь спасает проверка при загрузке конфига на допустимые имена спортов в глобальной мапке AvailableSportNames.
-	//  Наткнулся на обсуждение этой проблемы https://github.com/golang/go/issues/18478
-	//  Возможно как то можно иначе построить запрос, чтобы избежать такого рода подстановки или вообще есть иное решение, очень
-	//  хотелось бы узнать о нем.
-	firstPartOfQuery := `INSERT  INTO "` + sportName + `s" ("line") VALUES (`
-	addr := linesProviderAddr + sportName
+		polled()
 
-	resp, err := http.Get(addr)
-	if err != nil {
-		e <- err
-		return
+		select {
+		case <-abort:
+			return nil
+		case <-time.After(time.Duration(interval()) * time.Second):
+		}
 	}
-	defer resp.Body.Close()
+}
 
-	if resp.StatusCode != http.StatusOK {
-		e <- errors.New("status code isn't OK 200 (from LinesProvider)")
-		return
-	}
+func getFirstLine(in *ingester, sportName string, e chan<- error, n *sync.WaitGroup) {
+	defer n.Done()
 
-	dst := ParsedJSON{}
-	err = json.NewDecoder(resp.Body).Decode(&dst)
-	if err != nil {
+	if err := in.poll(sportName); err != nil {
 		e <- err
-		return
-	}
-
-	for _, line := range dst.Lines {
-		// хотя бы частичная параметризация
-		err = db.Exec(firstPartOfQuery+`?)`, line).Error
-		if err != nil {
-			e <- err
-			return
-		}
 	}
 }
 
 type ParsedJSON struct {
 	Lines map[string]string `json:"lines"`
+	// время, указанное LinesProvider'ом, если он его прислал
+	Time *time.Time `json:"time,omitempty"`
 }
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"log"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// invalidArgument - ошибка валидации поля запроса, клиент получает codes.InvalidArgument и google.rpc.BadRequest
+func invalidArgument(field, description string) error {
+	st, err := status.New(codes.InvalidArgument, description).WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: description}},
+	})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, description)
+	}
+	return st.Err()
+}
+
+// storageUnavailable - ошибка хранилища, ее подробности логируются, а клиент получает codes.Unavailable
+func storageUnavailable(err error) error {
+	log.Printf("Failed to get lines from the storage: %v\n", err)
+	return status.Error(codes.Unavailable, "There is a problem with getting lines from the storage")
+}
+
+// latestLineError - ошибка получения текущей линии: если линий спорта еще нет, клиент получает то же,
+// что и для необновляющихся линий (codes.FailedPrecondition), иначе - ошибку хранилища
+func latestLineError(err error) error {
+	if noLines, ok := err.(noLinesError); ok {
+		return staleLines(map[string]string{noLines.sportName: noLines.Error()})
+	}
+	return storageUnavailable(err)
+}
+
+// staleLines - линии спортов не обновляются (их воркеры не опрашивают LinesProvider), клиент получает
+// codes.FailedPrecondition и google.rpc.PreconditionFailure с нарушением для каждого такого спорта
+func staleLines(reasons map[string]string) error {
+	const description = "Lines of the requested sports are not synced with the Lines Provider"
+
+	var failure errdetails.PreconditionFailure
+	for sportName, reason := range reasons {
+		failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        "STALE",
+			Subject:     sportName,
+			Description: reason,
+		})
+	}
+
+	st, err := status.New(codes.FailedPrecondition, description).WithDetails(&failure)
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, description)
+	}
+	return st.Err()
+}
+
+// resourceExhausted - превышен лимит subject, клиент получает codes.ResourceExhausted и google.rpc.QuotaFailure
+func resourceExhausted(subject, description string) error {
+	st, err := status.New(codes.ResourceExhausted, description).WithDetails(&errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{{Subject: subject, Description: description}},
+	})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, description)
+	}
+	return st.Err()
+}
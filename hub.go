@@ -0,0 +1,160 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/softpro-junior-assignment/services"
+)
+
+// hubUpdate - последние линии спортов подписки на момент тика
+type hubUpdate struct {
+	lines map[string]services.Line
+	err   error
+}
+
+type subscription struct {
+	hub      *hub
+	interval uint32
+	sports   []string
+	// буфер на одно обновление: если подписчик не успел забрать предыдущее, оно заменяется более свежим
+	C chan hubUpdate
+}
+
+// Close отписывает подписчика от хаба, после этого обновления в C не приходят
+func (sub *subscription) Close() {
+	sub.hub.unsubscribe(sub)
+}
+
+// hub раздает подписчикам последние линии их спортов по тикам их интервала. Это не push: воркеры только обновляют
+// кэш (Publish), а на каждый интервал, запрошенный подписчиками, заводится один тикер, по которому линии берутся
+// из кэша и отправляются всем подписчикам с этим интервалом, даже если ничего не изменилось - EVERY_TICK присылает
+// дельты каждый интервал. Поэтому новая линия доходит до подписчика (в т.ч. ON_CHANGE и ON_THRESHOLD) на ближайшем
+// тике, т.е. с задержкой до одного интервала, зато не чаще, чем клиент запросил
+type hub struct {
+	cache *linesCache
+
+	mu     sync.Mutex
+	groups map[uint32]*hubGroup
+}
+
+type hubGroup struct {
+	subs map[*subscription]struct{}
+	stop chan struct{}
+}
+
+func newHub(cache *linesCache) *hub {
+	return &hub{
+		cache:  cache,
+		groups: make(map[uint32]*hubGroup),
+	}
+}
+
+// Publish сохраняет новую линию спорта в кэше, подписчикам она не отправляется: они получат ее на ближайшем тике
+// своего интервала
+func (h *hub) Publish(sportName string, line services.Line) {
+	h.cache.Set(sportName, line)
+}
+
+// Subscribe подписывает на линии sports с интервалом interval секунд
+func (h *hub) Subscribe(interval uint32, sports []string) *subscription {
+	sub := &subscription{
+		hub:      h,
+		interval: interval,
+		sports:   sports,
+		C:        make(chan hubUpdate, 1),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	group, found := h.groups[interval]
+	if !found {
+		group = &hubGroup{
+			subs: make(map[*subscription]struct{}),
+			stop: make(chan struct{}),
+		}
+		h.groups[interval] = group
+		go h.run(interval, group)
+	}
+	group.subs[sub] = struct{}{}
+
+	return sub
+}
+
+func (h *hub) unsubscribe(sub *subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	group, found := h.groups[sub.interval]
+	if !found {
+		return
+	}
+
+	delete(group.subs, sub)
+	if len(group.subs) == 0 {
+		close(group.stop)
+		delete(h.groups, sub.interval)
+	}
+}
+
+func (h *hub) run(interval uint32, group *hubGroup) {
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-group.stop:
+			return
+		case <-ticker.C:
+		}
+
+		h.mu.Lock()
+		subs := make([]*subscription, 0, len(group.subs))
+		for sub := range group.subs {
+			subs = append(subs, sub)
+		}
+		h.mu.Unlock()
+
+		// линии каждого спорта берутся из кэша один раз на тик, сколько бы подписчиков на него ни было
+		latest := make(map[string]services.Line)
+		errs := make(map[string]error)
+		for _, sub := range subs {
+			update := hubUpdate{lines: make(map[string]services.Line, len(sub.sports))}
+			for _, sportName := range sub.sports {
+				line, found := latest[sportName]
+				err := errs[sportName]
+				if !found && err == nil {
+					line, err = h.cache.Latest(sportName)
+					if err != nil {
+						errs[sportName] = err
+					} else {
+						latest[sportName] = line
+					}
+				}
+				if err != nil {
+					update.err = err
+					break
+				}
+				update.lines[sportName] = line
+			}
+			deliver(sub.C, update)
+		}
+	}
+}
+
+// deliver кладет update в c, заменяя им еще не забранное обновление
+func deliver(c chan hubUpdate, update hubUpdate) {
+	for {
+		select {
+		case c <- update:
+			return
+		default:
+		}
+
+		select {
+		case <-c:
+		default:
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+
+	"google.golang.org/grpc/peer"
+)
+
+type SubscriptionLimitsConfig struct {
+	MaxSports           uint   `json:"max_sports"` // макс. кол-во разных спортов в одном запросе; 0 - без ограничения
+	MinInterval         uint32 `json:"min_interval"`
+	MaxInterval         uint32 `json:"max_interval"`
+	MaxStreamsPerClient uint   `json:"max_streams_per_client"` // 0 - без ограничения
+	MaxStreams          uint   `json:"max_streams"`            // всего стримов на сервере; 0 - без ограничения
+}
+
+func DefaultSubscriptionLimitsConfig() SubscriptionLimitsConfig {
+	return SubscriptionLimitsConfig{
+		MaxSports:           0,
+		MinInterval:         1,
+		MaxInterval:         3600,
+		MaxStreamsPerClient: 10,
+		MaxStreams:          1000,
+	}
+}
+
+// streamLimiter считает открытые стримы, всего и по клиентам
+type streamLimiter struct {
+	maxPerClient uint
+	max          uint
+
+	mu        sync.Mutex
+	total     uint
+	perClient map[string]uint
+}
+
+func newStreamLimiter(limits SubscriptionLimitsConfig) *streamLimiter {
+	return &streamLimiter{
+		maxPerClient: limits.MaxStreamsPerClient,
+		max:          limits.MaxStreams,
+		perClient:    make(map[string]uint),
+	}
+}
+
+// Acquire занимает место под стрим клиента client, после завершения стрима его нужно освободить Release
+func (l *streamLimiter) Acquire(client string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.max != 0 && l.total >= l.max {
+		return resourceExhausted("max_streams", "The server has reached its limit of "+strconv.FormatUint(uint64(l.max), 10)+" concurrent streams")
+	}
+	if l.maxPerClient != 0 && l.perClient[client] >= l.maxPerClient {
+		return resourceExhausted("max_streams_per_client", "The client has reached its limit of "+strconv.FormatUint(uint64(l.maxPerClient), 10)+" concurrent streams")
+	}
+
+	l.total++
+	l.perClient[client]++
+	return nil
+}
+
+func (l *streamLimiter) Release(client string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.total--
+	l.perClient[client]--
+	if l.perClient[client] == 0 {
+		delete(l.perClient, client)
+	}
+}
+
+// clientID - идентификатор клиента для лимитов: имя аутентифицированного клиента или IP адрес, с которого пришел запрос
+func clientID(ctx context.Context) string {
+	if principal := principalFromContext(ctx); principal != nil {
+		return "principal:" + principal.name
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
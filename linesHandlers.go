@@ -0,0 +1,173 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/softpro-junior-assignment/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// linesHandlers - REST API для тех, кто не может работать с gRPC стримами, поверх той же логики, что и gRPC API
+type linesHandlers struct {
+	s *sportsLinesServer
+}
+
+// LineJSON - линия спорта в ответах REST API
+type LineJSON struct {
+	Sport        string     `json:"sport,omitempty"`
+	Line         float32    `json:"line"`
+	FetchedAt    time.Time  `json:"fetched_at"`
+	ProviderTime *time.Time `json:"provider_time,omitempty"`
+	Source       string     `json:"source"`
+	// линии спорта сейчас не обновляются, последняя из них может быть устаревшей
+	Stale bool `json:"stale,omitempty"`
+}
+
+type HistoryJSON struct {
+	Lines         []LineJSON `json:"lines"`
+	NextPageToken string     `json:"next_page_token,omitempty"`
+}
+
+func newLineJSON(sportName string, line services.Line) LineJSON {
+	return LineJSON{
+		Sport:        sportName,
+		Line:         line.Line,
+		FetchedAt:    line.FetchedAt,
+		ProviderTime: line.ProviderTime,
+		Source:       line.Source,
+	}
+}
+
+// renderError отдает ошибку логики gRPC API с соответствующим ее статусу HTTP кодом
+func renderError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+
+	code := http.StatusInternalServerError
+	switch st.Code() {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.Unauthenticated:
+		code = http.StatusUnauthorized
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.FailedPrecondition:
+		code = http.StatusConflict
+	case codes.ResourceExhausted:
+		code = http.StatusTooManyRequests
+	case codes.Unavailable:
+		code = http.StatusServiceUnavailable
+	}
+
+	RenderJSON(w, nil, code, st.Message())
+}
+
+// GET /api/v1/lines - текущие линии всех доступных клиенту спортов
+func (api linesHandlers) LatestAll(w http.ResponseWriter, r *http.Request) {
+	names := api.s.entitledSportNames(r.Context())
+	stale := api.s.staleReasons(names)
+
+	lines := make([]LineJSON, 0, len(names))
+	for _, name := range names {
+		latest, err := api.s.cache.Latest(name)
+		if _, ok := err.(noLinesError); ok {
+			// спорт только что добавлен, и его линий еще нет
+			continue
+		}
+		if err != nil {
+			renderError(w, storageUnavailable(err))
+			return
+		}
+
+		line := newLineJSON(name, latest)
+		_, line.Stale = stale[name]
+		lines = append(lines, line)
+	}
+
+	RenderJSON(w, lines, http.StatusOK, nil)
+}
+
+// GET /api/v1/lines/{sport} - текущая линия спорта
+func (api linesHandlers) Latest(w http.ResponseWriter, r *http.Request) {
+	name, ok := api.sport(w, r)
+	if !ok {
+		return
+	}
+
+	latest, err := api.s.cache.Latest(name)
+	if err != nil {
+		renderError(w, latestLineError(err))
+		return
+	}
+
+	line := newLineJSON(name, latest)
+	_, line.Stale = api.s.staleReasons([]string{name})[name]
+
+	RenderJSON(w, line, http.StatusOK, nil)
+}
+
+// GET /api/v1/lines/{sport}/history?from=&to=&page_size=&page_token= - линии спорта за промежуток [from, to)
+// в хронологическом порядке, from и to в RFC 3339, постранично, как и в GetLineHistory
+func (api linesHandlers) History(w http.ResponseWriter, r *http.Request) {
+	name, ok := api.sport(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+
+	var from, to *timestamppb.Timestamp
+	for param, ts := range map[string]**timestamppb.Timestamp{"from": &from, "to": &to} {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			RenderJSON(w, nil, http.StatusBadRequest, "'"+param+"' must be a time in the RFC 3339 format")
+			return
+		}
+		*ts = timestamppb.New(t)
+	}
+
+	var pageSize uint64
+	if value := query.Get("page_size"); value != "" {
+		var err error
+		if pageSize, err = strconv.ParseUint(value, 10, 32); err != nil {
+			RenderJSON(w, nil, http.StatusBadRequest, "'page_size' must be a non-negative number")
+			return
+		}
+	}
+
+	lines, nextPageToken, err := api.s.lineHistory(name, from, to, uint32(pageSize), query.Get("page_token"))
+	if err != nil {
+		renderError(w, err)
+		return
+	}
+
+	history := HistoryJSON{Lines: make([]LineJSON, 0, len(lines)), NextPageToken: nextPageToken}
+	for _, line := range lines {
+		history.Lines = append(history.Lines, newLineJSON("", line))
+	}
+
+	RenderJSON(w, history, http.StatusOK, nil)
+}
+
+// sport возвращает спорт из пути запроса, если он существует и доступен клиенту, иначе сам отвечает ошибкой,
+// одинаковой для несуществующих и недоступных спортов
+func (api linesHandlers) sport(w http.ResponseWriter, r *http.Request) (string, bool) {
+	name := mux.Vars(r)["sport"]
+	if !api.s.registry.Has(name) || !principalFromContext(r.Context()).Entitled(name) {
+		RenderJSON(w, nil, http.StatusNotFound, "No such sport exists")
+		return "", false
+	}
+
+	return name, true
+}
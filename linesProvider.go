@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	HTTPLinesProvider = "http"
+	FakeLinesProvider = "fake"
+	FileLinesProvider = "file"
+)
+
+// LinesProvider - источник линий, из которого воркеры наполняют хранилище
+type LinesProvider interface {
+	GetLines(sportName string) (ParsedJSON, error)
+	// Source возвращает идентификатор источника, он сохраняется вместе с каждой линией
+	Source() string
+}
+
+func NewLinesProvider(cfg Config) (LinesProvider, error) {
+	switch cfg.LinesProviderType {
+	case HTTPLinesProvider, "":
+		return NewHTTPLinesProvider(cfg.LinesProviderIP, cfg.LinesProviderPort, time.Duration(cfg.LinesProviderTimeout)*time.Second), nil
+	case FakeLinesProvider:
+		return NewFakeLinesProvider(nil), nil
+	case FileLinesProvider:
+		return NewFileLinesProvider(cfg.LinesProviderFile)
+	}
+
+	return nil, errors.New("Unknown lines provider type: " + cfg.LinesProviderType)
+}
+
+type httpLinesProvider struct {
+	addr string
+	// с таймаутом, чтобы зависший LinesProvider не останавливал воркер навсегда: ошибка запроса перезапускает его
+	client *http.Client
+}
+
+func NewHTTPLinesProvider(ip string, port uint, timeout time.Duration) LinesProvider {
+	return &httpLinesProvider{
+		addr:   fmt.Sprintf("http://%v:%d/api/v1/lines/", ip, port),
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *httpLinesProvider) Source() string {
+	return p.addr
+}
+
+func (p *httpLinesProvider) GetLines(sportName string) (ParsedJSON, error) {
+	dst := ParsedJSON{}
+
+	resp, err := p.client.Get(p.addr + sportName)
+	if err != nil {
+		return dst, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return dst, errors.New("status code isn't OK 200 (from LinesProvider, sport name: " + sportName + ")")
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&dst)
+	return dst, err
+}
+
+// fakeLinesProvider генерирует линии в памяти (случайное блуждание от начальных значений, для неизвестных спортов от 1),
+// нужен для прогона без живого LinesProvider
+type fakeLinesProvider struct {
+	mu    sync.Mutex
+	lines map[string]float32
+	rnd   *rand.Rand
+}
+
+func NewFakeLinesProvider(initial map[string]float32) *fakeLinesProvider {
+	lines := make(map[string]float32, len(initial))
+	for name, line := range initial {
+		lines[name] = line
+	}
+
+	return &fakeLinesProvider{
+		lines: lines,
+		rnd:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Set задает текущую линию спорта, следующий GetLines будет блуждать уже от нее
+func (p *fakeLinesProvider) Set(sportName string, line float32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lines[sportName] = line
+}
+
+func (p *fakeLinesProvider) Source() string {
+	return FakeLinesProvider
+}
+
+func (p *fakeLinesProvider) GetLines(sportName string) (ParsedJSON, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	line, found := p.lines[sportName]
+	if !found {
+		line = 1
+	}
+
+	line += (p.rnd.Float32() - 0.5) / 10
+	if line < 0 {
+		line = -line
+	}
+	p.lines[sportName] = line
+
+	return ParsedJSON{Lines: map[string]string{
+		strings.ToUpper(sportName): strconv.FormatFloat(float64(line), 'f', 3, 32),
+	}}, nil
+}
+
+// fileLinesProvider по кругу отдает записанные ответы LinesProvider'а из JSON файла вида
+// {"baseball": [{"lines": {"BASEBALL": "0.774"}}, ...], ...}
+type fileLinesProvider struct {
+	path      string
+	mu        sync.Mutex
+	responses map[string][]ParsedJSON
+	next      map[string]int
+}
+
+func NewFileLinesProvider(path string) (LinesProvider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	responses := make(map[string][]ParsedJSON)
+	if err := json.NewDecoder(f).Decode(&responses); err != nil {
+		return nil, err
+	}
+
+	return &fileLinesProvider{
+		path:      path,
+		responses: responses,
+		next:      make(map[string]int),
+	}, nil
+}
+
+func (p *fileLinesProvider) Source() string {
+	return FileLinesProvider + ":" + p.path
+}
+
+func (p *fileLinesProvider) GetLines(sportName string) (ParsedJSON, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	recorded := p.responses[sportName]
+	if len(recorded) == 0 {
+		return ParsedJSON{}, errors.New("file lines provider: no recorded responses for sport name: " + sportName)
+	}
+
+	i := p.next[sportName]
+	p.next[sportName] = (i + 1) % len(recorded)
+
+	return recorded[i], nil
+}
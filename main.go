@@ -1,10 +1,18 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 	"log"
 	"net"
 	"net/http"
@@ -15,18 +23,13 @@ import (
 	"time"
 
 	"github.com/softpro-junior-assignment/pb"
+	pbv2 "github.com/softpro-junior-assignment/pb/v2"
 	"github.com/softpro-junior-assignment/services"
 )
 
 // read only in non main goroutines
 var isSynced bool
 
-var AvailableSportNames = Set{
-	"soccer":   0,
-	"football": 0,
-	"baseball": 0,
-}
-
 func main() {
 	// flags' initialization
 
@@ -34,24 +37,116 @@ func main() {
 		"in production. This ensures that a .config file is "+
 		"provided before the application starts.")
 
-	setSchemaFlagPtr := flag.Bool("setschema", false, "WARNING: it is destructive action. Provide this flag "+
-		"to set the db schema. If '-prod' flag is provided, this flag will be ignored.")
-
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %v [flags] [migrate up|down|status]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
 	// the app's config's initialization
 
 	cfg := LoadConfig(*prodFlagPtr)
 
+	// migrations' commands
+
+	if flag.NArg() > 0 {
+		os.Exit(runCommand(cfg, flag.Args()))
+	}
+
 	// creating services
 
 	s, err := services.NewServices(
 		services.WithGorm(cfg.Database.Dialect(), cfg.Database.ConnectionInfo(), int(cfg.StorageConnNumOfAttempts), int(cfg.StorageConnIntervalBWAttempts)),
 		services.WithLogMode(cfg.Logmode),
-		services.WithSetSchema(!(*prodFlagPtr) && *setSchemaFlagPtr),
+		services.WithLines(),
+		services.WithSports(),
 	)
 	must(err)
-	defer s.Close()
+	// все запросы к хранилищу линий попадают в метрики
+	s.Lines = instrumentedLinesDB{s.Lines}
+
+	// сигналы ловятся с момента подключения к хранилищу, чтобы и во время запуска оно закрывалось корректно
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM, syscall.SIGINT)
+
+	pending, err := s.PendingMigrations()
+	if err != nil {
+		s.Close()
+		log.Fatalf("Failed to check the storage's migrations: %v", err)
+	}
+	if pending != 0 {
+		s.Close()
+		log.Fatalf("The storage has %d pending migration(s), run 'migrate up' first", pending)
+	}
+
+	// the sports' registry
+
+	registry := newSportRegistry(s.Sports)
+	if cfg.SportsSource == DatabaseSportsSource {
+		err = registry.Load()
+	} else {
+		for name, interval := range cfg.Intervals {
+			if err = registry.Register(name, interval); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		s.Close()
+		log.Fatalf("Failed to load the sports: %v", err)
+	}
+
+	// API v2 задает спорты id из хранилища, если они не совпадают с его enum, v2 не обслуживается
+	healthServices := []string{"SportsLinesService"}
+	v2Err := checkSportIDs(registry)
+	if v2Err != nil {
+		log.Printf("API v2 is disabled: %v\n", v2Err)
+	} else {
+		healthServices = append(healthServices, "sportslines.v2.SportsLinesService")
+	}
+
+	// the workers' supervisor, workers are launched after the first sync
+
+	abort := make(chan struct{})
+	sv := newSupervisor(
+		time.Duration(cfg.WorkerBackoffInitial)*time.Second,
+		time.Duration(cfg.WorkerBackoffMax)*time.Second,
+		cfg.WorkerMaxRestarts,
+		abort,
+	)
+
+	// TLS сертификаты общие для обоих серверов, по SIGHUP они перечитываются
+
+	var certs *certReloader
+	if cfg.TLS.Enabled() {
+		certs, err = newCertReloader(cfg.TLS)
+		if err != nil {
+			s.Close()
+			log.Fatalf("Failed to load the TLS certificates: %v", err)
+		}
+
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		go certs.Watch(hup, abort)
+	}
+
+	// кэш и хаб линий и сервис, общий для gRPC (обеих версий API) и REST API
+
+	cache := newLinesCache(s.Lines)
+	h := newHub(cache)
+	closeStreams := make(chan struct{})
+	linesServer := &sportsLinesServer{
+		lines:            s.Lines,
+		cache:            cache,
+		hub:              h,
+		registry:         registry,
+		sv:               sv,
+		historyMaxPoints: cfg.HistoryMaxPoints,
+		limits:           cfg.SubscriptionLimits,
+		streams:          newStreamLimiter(cfg.SubscriptionLimits),
+		sessions:         newSessionStore(time.Duration(cfg.SubscriptionResumeTTL) * time.Second),
+		shutdown:         closeStreams,
+	}
 
 	// starting HTTP server
 
@@ -63,53 +158,116 @@ func main() {
 		RenderJSON(w, nil, http.StatusMethodNotAllowed, "Wrong http method")
 	})
 
-	ReadyHandler := func(w http.ResponseWriter, r *http.Request) {
-		// правильно ли я понимаю, что именно в этом заключается проверка соединения с хранилищем?
-		// т.е. в использовании Ping()
-		if err := s.DB.DB().Ping(); err != nil {
-			RenderJSON(w, nil, http.StatusInternalServerError, "Failed to connect to the storage")
-			return
-		}
+	updates := newUpdateTracker()
+	prometheus.MustRegister(updates)
+
+	readiness := &readinessChecker{
+		ping:      s.DB.DB().Ping,
+		sv:        sv,
+		registry:  registry,
+		updates:   updates,
+		staleness: cfg.ReadinessStalenessFactor,
+		startedAt: time.Now(),
+		services:  healthServices,
+	}
 
-		if !isSynced {
-			RenderJSON(w, nil, http.StatusInternalServerError, "The storage is not synced with the Lines Provider")
+	ReadyHandler := func(w http.ResponseWriter, r *http.Request) {
+		report := readiness.Report()
+		if err := report.Err(); err != nil {
+			RenderJSON(w, report, http.StatusInternalServerError, err.Error())
 			return
 		}
 
+		RenderJSON(w, report, http.StatusOK, nil)
+	}
+	// процесс жив и обслуживает HTTP запросы, независимо от состояния хранилища и LinesProvider'а
+	LiveHandler := func(w http.ResponseWriter, r *http.Request) {
 		RenderJSON(w, nil, http.StatusOK, nil)
 	}
+	// клиенты аутентифицируются одинаково в gRPC и HTTP, /ready и /live доступны без аутентификации для проверок состояния
+	var auth *authenticator
+	if cfg.Auth.Enabled {
+		auth = newAuthenticator(cfg.Auth)
+		r.Use(auth.Middleware("/ready", "/live"))
+	}
+
 	r.HandleFunc("/ready", ReadyHandler).Methods(http.MethodGet)
+	r.HandleFunc("/live", LiveHandler).Methods(http.MethodGet)
+	r.Handle("/metrics", promhttp.Handler()).Methods(http.MethodGet)
+
+	api := linesHandlers{linesServer}
+	r.HandleFunc("/api/v1/lines", api.LatestAll).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/lines/{sport}", api.Latest).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/lines/{sport}/history", api.History).Methods(http.MethodGet)
+
+	// ошибки Serve обоих серверов, любая из них приводит к остановке сервиса
+	serveErrs := make(chan error, 2)
 
 	httpAdress := fmt.Sprintf(cfg.HTTPIP+":%d", cfg.HTTPPort)
+	httpServer := &http.Server{Addr: httpAdress, Handler: r}
 	go func() {
-		must(http.ListenAndServe(httpAdress, r))
+		var err error
+		if certs != nil {
+			httpServer.TLSConfig = certs.TLSConfig("h2", "http/1.1")
+			err = httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		if err != http.ErrServerClosed {
+			serveErrs <- err
+		}
 	}()
 	fmt.Printf("Started HTTP server on %v\n", httpAdress)
 
 	// try to sync the storage
 
-	linesProviderAddr := fmt.Sprintf("http://%v:%d/api/v1/lines/", cfg.LinesProviderIP, cfg.LinesProviderPort)
+	provider, err := NewLinesProvider(cfg)
+	if err != nil {
+		s.Close()
+		log.Fatalf("Failed to create the lines provider: %v", err)
+	}
+
+	in := &ingester{
+		lines:    s.Lines,
+		hub:      h,
+		provider: provider,
+		lineMin:  cfg.LineMin,
+		lineMax:  cfg.LineMax,
+		updates:  updates,
+	}
 
 	errs := make(chan error)
 	var n sync.WaitGroup
 	var globalErrSlice []error
 	var localErrSlice []error
 
+	// сигнал во время первой синхронизации прерывает запуск
+	var startupSig os.Signal
+firstSync:
 	for i := 0; i < int(cfg.FirstSyncNumOfAttempts); i++ {
-		for name := range cfg.Intervals {
+		for _, name := range registry.Names() {
 			n.Add(1)
 			go func(name string) {
-				getFirstLine(s.DB, name, linesProviderAddr, errs, &n)
+				getFirstLine(in, name, errs, &n)
 			}(name)
 		}
 
-		go func() {
+		go func(errs chan error) {
 			n.Wait()
 			close(errs)
-		}()
-
-		for e := range errs {
-			localErrSlice = append(localErrSlice, e)
+		}(errs)
+
+	attempt:
+		for {
+			select {
+			case e, ok := <-errs:
+				if !ok {
+					break attempt
+				}
+				localErrSlice = append(localErrSlice, e)
+			case startupSig = <-sigs:
+				break firstSync
+			}
 		}
 
 		if localErrSlice == nil {
@@ -122,11 +280,29 @@ func main() {
 		errs = make(chan error)
 
 		log.Printf("Can't sync the storage with the lines provider, next try in %d second(s) (%d attempt of %d)\n", cfg.FirstSyncIntervalBWAttempts, i+1, cfg.FirstSyncNumOfAttempts)
-		time.Sleep(time.Duration(cfg.FirstSyncIntervalBWAttempts) * time.Second)
+		select {
+		case startupSig = <-sigs:
+			break firstSync
+		case <-time.After(time.Duration(cfg.FirstSyncIntervalBWAttempts) * time.Second):
+		}
+	}
+
+	if startupSig != nil {
+		log.Printf("Got <%v> signal during the first sync, shutting down...", startupSig)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownTimeout)*time.Second)
+		if err := httpServer.Shutdown(ctx); err != nil {
+			log.Printf("Failed to shut down the HTTP server gracefully: %v\n", err)
+		}
+		cancel()
+		close(abort)
+		s.Close()
+
+		log.Println("Shut down")
+		os.Exit(0)
 	}
 
 	if !isSynced {
-		log.Println("Failed to sync the storage\n")
+		log.Println("Failed to sync the storage")
 		for _, err := range globalErrSlice {
 			log.Println(err)
 		}
@@ -135,22 +311,26 @@ func main() {
 
 	// launch workers
 
-	abort := make(chan struct{})
+	startWorker := func(sport services.Sport) {
+		sv.Start(sport.Name, func(abort <-chan struct{}, polled func()) error {
+			return getLine(func() uint {
+				current, _ := registry.Get(sport.Name)
+				return current.PollInterval
+			}, in, sport.Name, abort, polled)
+		})
+	}
+	for _, sport := range registry.All() {
+		startWorker(sport)
+	}
+	registry.OnAdd(startWorker)
 
-	for name, N := range cfg.Intervals {
-		n.Add(1)
-		go func(N uint, name string) {
-			getLine(N, s.DB, name, linesProviderAddr, abort, &n)
-		}(N, name)
+	if cfg.SportsSource == DatabaseSportsSource {
+		go registry.Watch(time.Duration(cfg.SportsReloadInterval)*time.Second, abort)
 	}
 
-	go func() {
-		sigs := make(chan os.Signal, 1)
-		signal.Notify(sigs, syscall.SIGTERM, syscall.SIGINT)
-		sig := <-sigs
-		log.Printf("Got <%v> signal, shutting down the workers...", sig)
-		close(abort)
-	}()
+	retentionDone := make(chan struct{})
+	retention := newRetentionJob(s.Lines, registry, cfg.Retention, time.Duration(cfg.RetentionInterval)*time.Second)
+	go retention.Run(abort, retentionDone)
 
 	// start gRPC server
 
@@ -160,12 +340,62 @@ func main() {
 		s.Close()
 		log.Fatalf("Failed to listen tcp port for gRPC server: %v", err)
 	}
-	server := grpc.NewServer()
-	pb.RegisterSportsLinesServiceServer(server, &sportsLinesServer{db: s.DB})
+	grpcOptions := []grpc.ServerOption{
+		// соединения с клиентами, которые не отвечают на пинги, закрываются, а вместе с ними и их стримы
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    time.Duration(cfg.GRPCKeepaliveTime) * time.Second,
+			Timeout: time.Duration(cfg.GRPCKeepaliveTimeout) * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             time.Duration(cfg.GRPCKeepaliveMinTime) * time.Second,
+			PermitWithoutStream: true,
+		}),
+	}
+	if auth != nil {
+		grpcOptions = append(grpcOptions, grpc.UnaryInterceptor(auth.UnaryInterceptor), grpc.StreamInterceptor(auth.StreamInterceptor))
+	}
+	if certs != nil {
+		grpcOptions = append(grpcOptions, grpc.Creds(credentials.NewTLS(certs.TLSConfig("h2"))))
+	}
+	server := grpc.NewServer(grpcOptions...)
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(server, hs)
+	reflection.Register(server)
+	go readiness.WatchHealth(hs, time.Duration(cfg.HealthCheckInterval)*time.Second, abort)
+	// обе версии API обслуживаются одним и тем же сервером, с общими лимитами стримов
+	pb.RegisterSportsLinesServiceServer(server, linesServer)
+	if v2Err == nil {
+		pbv2.RegisterSportsLinesServiceServer(server, sportsLinesServerV2{linesServer})
+	}
 	go func() {
-		must(server.Serve(lis))
+		if err := server.Serve(lis); err != nil {
+			serveErrs <- err
+		}
 	}()
 	fmt.Printf("Started gRPC server on %v\nSend SIGINT or SIGTERM to exit correctly\n", grpcAdress)
 
-	n.Wait()
+	// graceful shutdown
+
+	exitCode := 0
+	select {
+	case sig := <-sigs:
+		log.Printf("Got <%v> signal, shutting down...", sig)
+	case err := <-serveErrs:
+		log.Printf("Server failed, shutting down: %v", err)
+		exitCode = 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownTimeout)*time.Second)
+	shutdown(ctx, server, func() {
+		hs.Shutdown()
+		close(closeStreams)
+	}, httpServer, func() { close(abort) }, func() {
+		sv.Wait()
+		<-retentionDone
+	})
+	cancel()
+	s.Close()
+
+	log.Println("Shut down")
+	os.Exit(exitCode)
 }
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/softpro-junior-assignment/services"
+)
+
+// метрики отдаются по HTTP в GET /metrics
+var (
+	providerRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "sportslines_provider_request_duration_seconds",
+		Help: "Latency of the requests to the Lines Provider.",
+	}, []string{"sport"})
+
+	providerErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sportslines_provider_errors_total",
+		Help: "Failed requests to the Lines Provider (reason: request) and rejected payloads (reason: payload).",
+	}, []string{"sport", "reason"})
+
+	rowsInserted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sportslines_rows_inserted_total",
+		Help: "Lines inserted into the storage.",
+	}, []string{"sport"})
+
+	activeStreams = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sportslines_active_streams",
+		Help: "Open SubscribeOnSportsLines streams of all API versions.",
+	})
+
+	streamMessagesSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sportslines_stream_messages_sent_total",
+		Help: "Messages sent to SubscribeOnSportsLines streams by type: lines, deltas or heartbeat.",
+	}, []string{"type"})
+
+	storageQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "sportslines_storage_query_duration_seconds",
+		Help: "Latency of the lines storage queries.",
+	}, []string{"query"})
+
+	retentionRuns = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "sportslines_retention_runs_total",
+		Help: "Runs of the retention job.",
+	})
+
+	retentionErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "sportslines_retention_errors_total",
+		Help: "Failed downsampling and deletion of a sport's lines by the retention job.",
+	})
+
+	retentionRows = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sportslines_retention_rows_total",
+		Help: "Lines processed by the retention job by action: downsampled (replaced), inserted (per-minute averages) or deleted.",
+	}, []string{"action"})
+
+	retentionLastRunDuration = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sportslines_retention_last_run_duration_seconds",
+		Help: "Duration of the last run of the retention job.",
+	})
+
+	retentionLastRun = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sportslines_retention_last_run_timestamp_seconds",
+		Help: "Unix time of the last run of the retention job.",
+	})
+)
+
+// updateTracker хранит время последнего успешного обновления линий каждого спорта
+// и отдает в метриках, сколько секунд прошло с него
+type updateTracker struct {
+	desc *prometheus.Desc
+
+	mu   sync.RWMutex
+	last map[string]time.Time
+}
+
+func newUpdateTracker() *updateTracker {
+	return &updateTracker{
+		desc: prometheus.NewDesc(
+			"sportslines_seconds_since_last_update",
+			"Seconds since the lines of the sport were last successfully fetched and stored.",
+			[]string{"sport"}, nil,
+		),
+		last: make(map[string]time.Time),
+	}
+}
+
+func (t *updateTracker) Set(sportName string, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.last[sportName] = at
+}
+
+func (t *updateTracker) Get(sportName string) (time.Time, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	at, found := t.last[sportName]
+	return at, found
+}
+
+func (t *updateTracker) Describe(ch chan<- *prometheus.Desc) {
+	ch <- t.desc
+}
+
+func (t *updateTracker) Collect(ch chan<- prometheus.Metric) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for sportName, at := range t.last {
+		ch <- prometheus.MustNewConstMetric(t.desc, prometheus.GaugeValue, time.Since(at).Seconds(), sportName)
+	}
+}
+
+// instrumentedLinesDB измеряет время запросов к хранилищу линий
+type instrumentedLinesDB struct {
+	services.LinesDB
+}
+
+func observeQuery(query string, start time.Time) {
+	storageQueryDuration.WithLabelValues(query).Observe(time.Since(start).Seconds())
+}
+
+func (db instrumentedLinesDB) Insert(sportName string, poll services.Poll) error {
+	defer observeQuery("insert", time.Now())
+	return db.LinesDB.Insert(sportName, poll)
+}
+
+func (db instrumentedLinesDB) Latest(sportName string) (*services.Line, error) {
+	defer observeQuery("latest", time.Now())
+	return db.LinesDB.Latest(sportName)
+}
+
+func (db instrumentedLinesDB) History(sportName string, from, to time.Time, after *services.HistoryCursor, limit uint) ([]services.Line, error) {
+	defer observeQuery("history", time.Now())
+	return db.LinesDB.History(sportName, from, to, after, limit)
+}
+
+func (db instrumentedLinesDB) Downsample(sportName string, before time.Time) (int64, int64, error) {
+	defer observeQuery("downsample", time.Now())
+	return db.LinesDB.Downsample(sportName, before)
+}
+
+func (db instrumentedLinesDB) DeleteBefore(sportName string, before time.Time) (int64, error) {
+	defer observeQuery("delete_before", time.Now())
+	return db.LinesDB.DeleteBefore(sportName, before)
+}
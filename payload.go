@@ -0,0 +1,96 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldError - ошибка в одном поле ответа LinesProvider'а, Field в виде "lines.BASEBALL"
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Reason
+}
+
+// PayloadError - все ошибки в ответе LinesProvider'а на запрос линий спорта
+type PayloadError struct {
+	SportName string
+	Fields    []FieldError
+}
+
+func (e *PayloadError) Error() string {
+	reasons := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		reasons[i] = field.Error()
+	}
+	return "Invalid payload from LinesProvider (sport name: " + e.SportName + "): " + strings.Join(reasons, "; ")
+}
+
+// parseLines проверяет ответ LinesProvider'а на запрос линий спорта sportName: в lines должен быть единственный ключ,
+// совпадающий с именем спорта (без учета регистра), а значение - быть конечным числом в промежутке [min, max]
+func parseLines(sportName string, dst ParsedJSON, min, max float64) ([]float64, error) {
+	payloadErr := &PayloadError{SportName: sportName}
+
+	if len(dst.Lines) == 0 {
+		payloadErr.Fields = append(payloadErr.Fields, FieldError{Field: "lines", Reason: "no lines provided"})
+		return nil, payloadErr
+	}
+
+	keys := make([]string, 0, len(dst.Lines))
+	for key := range dst.Lines {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// ключи, отличающиеся только регистром, дали бы несколько линий за один опрос
+	var matching []string
+	for _, key := range keys {
+		if strings.EqualFold(key, sportName) {
+			matching = append(matching, key)
+		}
+	}
+	if len(matching) > 1 {
+		payloadErr.Fields = append(payloadErr.Fields, FieldError{Field: "lines", Reason: "more than one key matches the requested sport name: " + strings.Join(matching, ", ")})
+	}
+
+	lines := make([]float64, 0, len(dst.Lines))
+	for _, key := range keys {
+		field := "lines." + key
+
+		if !strings.EqualFold(key, sportName) {
+			payloadErr.Fields = append(payloadErr.Fields, FieldError{Field: field, Reason: "the key doesn't match the requested sport name"})
+			continue
+		}
+
+		line, err := strconv.ParseFloat(dst.Lines[key], 64)
+		if err != nil {
+			payloadErr.Fields = append(payloadErr.Fields, FieldError{Field: field, Reason: "not a number: " + strconv.Quote(dst.Lines[key])})
+			continue
+		}
+
+		switch {
+		case math.IsNaN(line) || math.IsInf(line, 0):
+			payloadErr.Fields = append(payloadErr.Fields, FieldError{Field: field, Reason: "not a finite number"})
+			continue
+		case line < min || line > max:
+			payloadErr.Fields = append(payloadErr.Fields, FieldError{Field: field, Reason: "out of the range [" + formatFloat(min) + ", " + formatFloat(max) + "]"})
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	if payloadErr.Fields != nil {
+		return nil, payloadErr
+	}
+	return lines, nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		lines  map[string]string
+		want   float64
+		fields []string // поля с ошибками, nil - ответ корректен
+	}{
+		{"valid", map[string]string{"SOCCER": "1.25"}, 1.25, nil},
+		{"lowercase key", map[string]string{"soccer": "0"}, 0, nil},
+		{"range bound", map[string]string{"SOCCER": "10"}, 10, nil},
+		{"no lines", nil, 0, []string{"lines"}},
+		{"key mismatch", map[string]string{"BASEBALL": "1"}, 0, []string{"lines.BASEBALL"}},
+		{"extra key", map[string]string{"SOCCER": "1", "BASEBALL": "1"}, 0, []string{"lines.BASEBALL"}},
+		{"duplicate keys", map[string]string{"SOCCER": "1", "soccer": "2"}, 0, []string{"lines"}},
+		{"not a number", map[string]string{"SOCCER": "abc"}, 0, []string{"lines.SOCCER"}},
+		{"NaN", map[string]string{"SOCCER": "NaN"}, 0, []string{"lines.SOCCER"}},
+		{"Inf", map[string]string{"SOCCER": "+Inf"}, 0, []string{"lines.SOCCER"}},
+		{"-Inf", map[string]string{"SOCCER": "-Inf"}, 0, []string{"lines.SOCCER"}},
+		{"below the range", map[string]string{"SOCCER": "-0.5"}, 0, []string{"lines.SOCCER"}},
+		{"above the range", map[string]string{"SOCCER": "10.01"}, 0, []string{"lines.SOCCER"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := parseLines("soccer", ParsedJSON{Lines: tt.lines}, 0, 10)
+
+			if tt.fields == nil {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if len(lines) != 1 || lines[0] != tt.want {
+					t.Fatalf("Expected [%v], got %v", tt.want, lines)
+				}
+				return
+			}
+
+			var payloadErr *PayloadError
+			if !errors.As(err, &payloadErr) {
+				t.Fatalf("Expected a payload error, got %v", err)
+			}
+			if lines != nil {
+				t.Fatalf("Expected no lines, got %v", lines)
+			}
+			if len(payloadErr.Fields) != len(tt.fields) {
+				t.Fatalf("Expected errors in %v, got %v", tt.fields, payloadErr)
+			}
+			for i, field := range tt.fields {
+				if payloadErr.Fields[i].Field != field {
+					t.Fatalf("Expected errors in %v, got %v", tt.fields, payloadErr)
+				}
+			}
+		})
+	}
+}
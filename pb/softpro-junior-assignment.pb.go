@@ -14,6 +14,7 @@ import (
 	status "google.golang.org/grpc/status"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -29,13 +30,74 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+// Когда присылать дельты. В режимах ON_CHANGE и ON_THRESHOLD в ответ попадают только изменившиеся спорты,
+// а изменение считается относительно линии, дельта от которой была прислана последней
+type SubscriptionMode int32
+
+const (
+	SubscriptionMode_EVERY_TICK   SubscriptionMode = 0 // каждый интервал, даже если дельты нулевые
+	SubscriptionMode_ON_CHANGE    SubscriptionMode = 1 // только если линия изменилась
+	SubscriptionMode_ON_THRESHOLD SubscriptionMode = 2 // только если линия изменилась больше, чем на threshold
+)
+
+// Enum value maps for SubscriptionMode.
+var (
+	SubscriptionMode_name = map[int32]string{
+		0: "EVERY_TICK",
+		1: "ON_CHANGE",
+		2: "ON_THRESHOLD",
+	}
+	SubscriptionMode_value = map[string]int32{
+		"EVERY_TICK":   0,
+		"ON_CHANGE":    1,
+		"ON_THRESHOLD": 2,
+	}
+)
+
+func (x SubscriptionMode) Enum() *SubscriptionMode {
+	p := new(SubscriptionMode)
+	*p = x
+	return p
+}
+
+func (x SubscriptionMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SubscriptionMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_softpro_junior_assignment_proto_enumTypes[0].Descriptor()
+}
+
+func (SubscriptionMode) Type() protoreflect.EnumType {
+	return &file_softpro_junior_assignment_proto_enumTypes[0]
+}
+
+func (x SubscriptionMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SubscriptionMode.Descriptor instead.
+func (SubscriptionMode) EnumDescriptor() ([]byte, []int) {
+	return file_softpro_junior_assignment_proto_rawDescGZIP(), []int{0}
+}
+
+// Можно определить enum для допустимых имен спортов и пересылать будем гораздо меньший объем данных,
+// но зато на сервере будет больше работы по отображению интов на строки и обратно в операциях
+// по подготовке перед приемом/отдачей сообщений.
 type SubscribeOnSportsLinesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Interval   uint32   `protobuf:"varint,1,opt,name=interval,proto3" json:"interval,omitempty"`
-	SportNames []string `protobuf:"bytes,2,rep,name=sport_names,json=sportNames,proto3" json:"sport_names,omitempty"`
+	Interval   uint32           `protobuf:"varint,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	SportNames []string         `protobuf:"bytes,2,rep,name=sport_names,json=sportNames,proto3" json:"sport_names,omitempty"`
+	Mode       SubscriptionMode `protobuf:"varint,3,opt,name=mode,proto3,enum=SubscriptionMode" json:"mode,omitempty"`
+	Threshold  float32          `protobuf:"fixed32,4,opt,name=threshold,proto3" json:"threshold,omitempty"` // только для ON_THRESHOLD, должен быть больше 0
+	// resume_token из последнего полученного ответа оборвавшегося стрима, только в первом запросе нового стрима:
+	// сервер продолжит присылать дельты от тех же линий, что и раньше, а seq - с того же номера
+	ResumeToken string `protobuf:"bytes,5,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	// как часто в секундах присылать heartbeat, если других ответов в стриме не было; 0 - не присылать
+	HeartbeatInterval uint32 `protobuf:"varint,6,opt,name=heartbeat_interval,json=heartbeatInterval,proto3" json:"heartbeat_interval,omitempty"`
 }
 
 func (x *SubscribeOnSportsLinesRequest) Reset() {
@@ -84,12 +146,43 @@ func (x *SubscribeOnSportsLinesRequest) GetSportNames() []string {
 	return nil
 }
 
+func (x *SubscribeOnSportsLinesRequest) GetMode() SubscriptionMode {
+	if x != nil {
+		return x.Mode
+	}
+	return SubscriptionMode_EVERY_TICK
+}
+
+func (x *SubscribeOnSportsLinesRequest) GetThreshold() float32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *SubscribeOnSportsLinesRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *SubscribeOnSportsLinesRequest) GetHeartbeatInterval() uint32 {
+	if x != nil {
+		return x.HeartbeatInterval
+	}
+	return 0
+}
+
 type SubscribeOnSportsLinesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SportInfos []*SportInfo `protobuf:"bytes,1,rep,name=sport_infos,json=sportInfos,proto3" json:"sport_infos,omitempty"`
+	SportInfos  []*SportInfo `protobuf:"bytes,1,rep,name=sport_infos,json=sportInfos,proto3" json:"sport_infos,omitempty"`
+	Seq         uint64       `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`                                   // номер ответа в стриме, начиная с 1, продолжается при возобновлении стрима
+	ResumeToken string       `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"` // токен для возобновления стрима, пока он не истек
+	Heartbeat   *Heartbeat   `protobuf:"bytes,4,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`                        // только в heartbeat'ах, линий в них нет, а seq - номер последнего ответа с линиями
 }
 
 func (x *SubscribeOnSportsLinesResponse) Reset() {
@@ -131,6 +224,83 @@ func (x *SubscribeOnSportsLinesResponse) GetSportInfos() []*SportInfo {
 	return nil
 }
 
+func (x *SubscribeOnSportsLinesResponse) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SubscribeOnSportsLinesResponse) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *SubscribeOnSportsLinesResponse) GetHeartbeat() *Heartbeat {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return nil
+}
+
+// Heartbeat отличает тихий стрим (например, в режиме ON_CHANGE) от оборвавшегося
+type Heartbeat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=server_time,json=serverTime,proto3" json:"server_time,omitempty"`
+	Synced     bool                   `protobuf:"varint,2,opt,name=synced,proto3" json:"synced,omitempty"` // линии всех спортов стрима сейчас обновляются
+}
+
+func (x *Heartbeat) Reset() {
+	*x = Heartbeat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_softpro_junior_assignment_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Heartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Heartbeat) ProtoMessage() {}
+
+func (x *Heartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_softpro_junior_assignment_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Heartbeat.ProtoReflect.Descriptor instead.
+func (*Heartbeat) Descriptor() ([]byte, []int) {
+	return file_softpro_junior_assignment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Heartbeat) GetServerTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ServerTime
+	}
+	return nil
+}
+
+func (x *Heartbeat) GetSynced() bool {
+	if x != nil {
+		return x.Synced
+	}
+	return false
+}
+
 type SportInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -143,7 +313,7 @@ type SportInfo struct {
 func (x *SportInfo) Reset() {
 	*x = SportInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_softpro_junior_assignment_proto_msgTypes[2]
+		mi := &file_softpro_junior_assignment_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -156,7 +326,7 @@ func (x *SportInfo) String() string {
 func (*SportInfo) ProtoMessage() {}
 
 func (x *SportInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_softpro_junior_assignment_proto_msgTypes[2]
+	mi := &file_softpro_junior_assignment_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -169,7 +339,7 @@ func (x *SportInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SportInfo.ProtoReflect.Descriptor instead.
 func (*SportInfo) Descriptor() ([]byte, []int) {
-	return file_softpro_junior_assignment_proto_rawDescGZIP(), []int{2}
+	return file_softpro_junior_assignment_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SportInfo) GetName() string {
@@ -186,36 +356,412 @@ func (x *SportInfo) GetLine() float32 {
 	return 0
 }
 
+type GetLatestLinesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SportNames []string `protobuf:"bytes,1,rep,name=sport_names,json=sportNames,proto3" json:"sport_names,omitempty"`
+}
+
+func (x *GetLatestLinesRequest) Reset() {
+	*x = GetLatestLinesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_softpro_junior_assignment_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLatestLinesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestLinesRequest) ProtoMessage() {}
+
+func (x *GetLatestLinesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_softpro_junior_assignment_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestLinesRequest.ProtoReflect.Descriptor instead.
+func (*GetLatestLinesRequest) Descriptor() ([]byte, []int) {
+	return file_softpro_junior_assignment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetLatestLinesRequest) GetSportNames() []string {
+	if x != nil {
+		return x.SportNames
+	}
+	return nil
+}
+
+type GetLatestLinesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SportInfos []*SportInfo `protobuf:"bytes,1,rep,name=sport_infos,json=sportInfos,proto3" json:"sport_infos,omitempty"` // текущие линии в порядке запроса
+}
+
+func (x *GetLatestLinesResponse) Reset() {
+	*x = GetLatestLinesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_softpro_junior_assignment_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLatestLinesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestLinesResponse) ProtoMessage() {}
+
+func (x *GetLatestLinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_softpro_junior_assignment_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestLinesResponse.ProtoReflect.Descriptor instead.
+func (*GetLatestLinesResponse) Descriptor() ([]byte, []int) {
+	return file_softpro_junior_assignment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetLatestLinesResponse) GetSportInfos() []*SportInfo {
+	if x != nil {
+		return x.SportInfos
+	}
+	return nil
+}
+
+// Линии спорта за промежуток [from, to) в хронологическом порядке, постранично
+type GetLineHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SportName string                 `protobuf:"bytes,1,opt,name=sport_name,json=sportName,proto3" json:"sport_name,omitempty"`
+	From      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`                            // если не указано, то с самой первой линии
+	To        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`                                // если не указано, то до текущего момента
+	PageSize  uint32                 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // не больше максимального кол-ва линий в ответе, заданного на сервере; 0 - максимальное
+	PageToken string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token из предыдущего ответа
+}
+
+func (x *GetLineHistoryRequest) Reset() {
+	*x = GetLineHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_softpro_junior_assignment_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLineHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLineHistoryRequest) ProtoMessage() {}
+
+func (x *GetLineHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_softpro_junior_assignment_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLineHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetLineHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_softpro_junior_assignment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetLineHistoryRequest) GetSportName() string {
+	if x != nil {
+		return x.SportName
+	}
+	return ""
+}
+
+func (x *GetLineHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetLineHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetLineHistoryRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetLineHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetLineHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lines         []*HistoryLine `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	NextPageToken string         `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // пустой, если это последняя страница
+}
+
+func (x *GetLineHistoryResponse) Reset() {
+	*x = GetLineHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_softpro_junior_assignment_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLineHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLineHistoryResponse) ProtoMessage() {}
+
+func (x *GetLineHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_softpro_junior_assignment_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLineHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetLineHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_softpro_junior_assignment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetLineHistoryResponse) GetLines() []*HistoryLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *GetLineHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type HistoryLine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Line         float32                `protobuf:"fixed32,1,opt,name=line,proto3" json:"line,omitempty"`
+	FetchedAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+	ProviderTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=provider_time,json=providerTime,proto3" json:"provider_time,omitempty"` // если LinesProvider его прислал
+	Source       string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+}
+
+func (x *HistoryLine) Reset() {
+	*x = HistoryLine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_softpro_junior_assignment_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HistoryLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryLine) ProtoMessage() {}
+
+func (x *HistoryLine) ProtoReflect() protoreflect.Message {
+	mi := &file_softpro_junior_assignment_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryLine.ProtoReflect.Descriptor instead.
+func (*HistoryLine) Descriptor() ([]byte, []int) {
+	return file_softpro_junior_assignment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HistoryLine) GetLine() float32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *HistoryLine) GetFetchedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return nil
+}
+
+func (x *HistoryLine) GetProviderTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ProviderTime
+	}
+	return nil
+}
+
+func (x *HistoryLine) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
 var File_softpro_junior_assignment_proto protoreflect.FileDescriptor
 
 var file_softpro_junior_assignment_proto_rawDesc = []byte{
 	0x0a, 0x1f, 0x73, 0x6f, 0x66, 0x74, 0x70, 0x72, 0x6f, 0x2d, 0x6a, 0x75, 0x6e, 0x69, 0x6f, 0x72,
 	0x2d, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0x5c, 0x0a, 0x1d, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4f, 0x6e,
-	0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1f,
-	0x0a, 0x0b, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x22,
-	0x4d, 0x0a, 0x1e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4f, 0x6e, 0x53, 0x70,
-	0x6f, 0x72, 0x74, 0x73, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x2b, 0x0a, 0x0b, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e,
-	0x66, 0x6f, 0x52, 0x0a, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x22, 0x33,
-	0x0a, 0x09, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x6c,
-	0x69, 0x6e, 0x65, 0x32, 0x73, 0x0a, 0x12, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x4c, 0x69, 0x6e,
-	0x65, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5d, 0x0a, 0x16, 0x53, 0x75, 0x62,
+	0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xf3, 0x01, 0x0a, 0x1d, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x4f, 0x6e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x4e, 0x61, 0x6d, 0x65,
+	0x73, 0x12, 0x25, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x11, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f,
+	0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x74, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65,
+	0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x68, 0x65, 0x61,
+	0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0xac, 0x01, 0x0a, 0x1e, 0x53, 0x75, 0x62,
 	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4f, 0x6e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x4c, 0x69,
-	0x6e, 0x65, 0x73, 0x12, 0x1e, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4f,
-	0x6e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4f,
-	0x6e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x6f, 0x66, 0x74, 0x70, 0x72, 0x6f, 0x2d, 0x6a,
-	0x75, 0x6e, 0x69, 0x6f, 0x72, 0x2d, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74,
-	0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x0b, 0x73,
+	0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0a, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x73, 0x70,
+	0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65,
+	0x73, 0x75, 0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x28, 0x0a,
+	0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0a, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x09, 0x68, 0x65,
+	0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x22, 0x60, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74,
+	0x62, 0x65, 0x61, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x22, 0x33, 0x0a, 0x09, 0x53, 0x70, 0x6f,
+	0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69,
+	0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0x38,
+	0x0a, 0x15, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x70, 0x6f, 0x72, 0x74,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x70,
+	0x6f, 0x72, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x45, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x4c,
+	0x61, 0x74, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2b, 0x0a, 0x0b, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x22,
+	0xce, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x70, 0x6f,
+	0x72, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x70, 0x6f, 0x72, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x02, 0x74, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x22, 0x64, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x6c, 0x69,
+	0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6e, 0x65, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x26,
+	0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xb5, 0x01, 0x0a, 0x0b, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x66, 0x65,
+	0x74, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x66, 0x65, 0x74, 0x63,
+	0x68, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3f, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2a, 0x43,
+	0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f,
+	0x64, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x45, 0x56, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x49, 0x43, 0x4b,
+	0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x4f, 0x4e, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x10,
+	0x01, 0x12, 0x10, 0x0a, 0x0c, 0x4f, 0x4e, 0x5f, 0x54, 0x48, 0x52, 0x45, 0x53, 0x48, 0x4f, 0x4c,
+	0x44, 0x10, 0x02, 0x32, 0xf9, 0x01, 0x0a, 0x12, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x4c, 0x69,
+	0x6e, 0x65, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5d, 0x0a, 0x16, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4f, 0x6e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x4c,
+	0x69, 0x6e, 0x65, 0x73, 0x12, 0x1e, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x4f, 0x6e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x4f, 0x6e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x41, 0x0a, 0x0e, 0x47, 0x65, 0x74,
+	0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x47, 0x65,
+	0x74, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x4c,
+	0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0e,
+	0x47, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x16,
+	0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x65,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x6f,
+	0x66, 0x74, 0x70, 0x72, 0x6f, 0x2d, 0x6a, 0x75, 0x6e, 0x69, 0x6f, 0x72, 0x2d, 0x61, 0x73, 0x73,
+	0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -230,21 +776,43 @@ func file_softpro_junior_assignment_proto_rawDescGZIP() []byte {
 	return file_softpro_junior_assignment_proto_rawDescData
 }
 
-var file_softpro_junior_assignment_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_softpro_junior_assignment_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_softpro_junior_assignment_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_softpro_junior_assignment_proto_goTypes = []interface{}{
-	(*SubscribeOnSportsLinesRequest)(nil),  // 0: SubscribeOnSportsLinesRequest
-	(*SubscribeOnSportsLinesResponse)(nil), // 1: SubscribeOnSportsLinesResponse
-	(*SportInfo)(nil),                      // 2: SportInfo
+	(SubscriptionMode)(0),                  // 0: SubscriptionMode
+	(*SubscribeOnSportsLinesRequest)(nil),  // 1: SubscribeOnSportsLinesRequest
+	(*SubscribeOnSportsLinesResponse)(nil), // 2: SubscribeOnSportsLinesResponse
+	(*Heartbeat)(nil),                      // 3: Heartbeat
+	(*SportInfo)(nil),                      // 4: SportInfo
+	(*GetLatestLinesRequest)(nil),          // 5: GetLatestLinesRequest
+	(*GetLatestLinesResponse)(nil),         // 6: GetLatestLinesResponse
+	(*GetLineHistoryRequest)(nil),          // 7: GetLineHistoryRequest
+	(*GetLineHistoryResponse)(nil),         // 8: GetLineHistoryResponse
+	(*HistoryLine)(nil),                    // 9: HistoryLine
+	(*timestamppb.Timestamp)(nil),          // 10: google.protobuf.Timestamp
 }
 var file_softpro_junior_assignment_proto_depIdxs = []int32{
-	2, // 0: SubscribeOnSportsLinesResponse.sport_infos:type_name -> SportInfo
-	0, // 1: SportsLinesService.SubscribeOnSportsLines:input_type -> SubscribeOnSportsLinesRequest
-	1, // 2: SportsLinesService.SubscribeOnSportsLines:output_type -> SubscribeOnSportsLinesResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: SubscribeOnSportsLinesRequest.mode:type_name -> SubscriptionMode
+	4,  // 1: SubscribeOnSportsLinesResponse.sport_infos:type_name -> SportInfo
+	3,  // 2: SubscribeOnSportsLinesResponse.heartbeat:type_name -> Heartbeat
+	10, // 3: Heartbeat.server_time:type_name -> google.protobuf.Timestamp
+	4,  // 4: GetLatestLinesResponse.sport_infos:type_name -> SportInfo
+	10, // 5: GetLineHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	10, // 6: GetLineHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	9,  // 7: GetLineHistoryResponse.lines:type_name -> HistoryLine
+	10, // 8: HistoryLine.fetched_at:type_name -> google.protobuf.Timestamp
+	10, // 9: HistoryLine.provider_time:type_name -> google.protobuf.Timestamp
+	1,  // 10: SportsLinesService.SubscribeOnSportsLines:input_type -> SubscribeOnSportsLinesRequest
+	5,  // 11: SportsLinesService.GetLatestLines:input_type -> GetLatestLinesRequest
+	7,  // 12: SportsLinesService.GetLineHistory:input_type -> GetLineHistoryRequest
+	2,  // 13: SportsLinesService.SubscribeOnSportsLines:output_type -> SubscribeOnSportsLinesResponse
+	6,  // 14: SportsLinesService.GetLatestLines:output_type -> GetLatestLinesResponse
+	8,  // 15: SportsLinesService.GetLineHistory:output_type -> GetLineHistoryResponse
+	13, // [13:16] is the sub-list for method output_type
+	10, // [10:13] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_softpro_junior_assignment_proto_init() }
@@ -278,6 +846,18 @@ func file_softpro_junior_assignment_proto_init() {
 			}
 		}
 		file_softpro_junior_assignment_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Heartbeat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_softpro_junior_assignment_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SportInfo); i {
 			case 0:
 				return &v.state
@@ -289,19 +869,80 @@ func file_softpro_junior_assignment_proto_init() {
 				return nil
 			}
 		}
+		file_softpro_junior_assignment_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLatestLinesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_softpro_junior_assignment_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLatestLinesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_softpro_junior_assignment_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLineHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_softpro_junior_assignment_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLineHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_softpro_junior_assignment_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoryLine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_softpro_junior_assignment_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   3,
+			NumEnums:      1,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_softpro_junior_assignment_proto_goTypes,
 		DependencyIndexes: file_softpro_junior_assignment_proto_depIdxs,
+		EnumInfos:         file_softpro_junior_assignment_proto_enumTypes,
 		MessageInfos:      file_softpro_junior_assignment_proto_msgTypes,
 	}.Build()
 	File_softpro_junior_assignment_proto = out.File
@@ -323,6 +964,8 @@ const _ = grpc.SupportPackageIsVersion6
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type SportsLinesServiceClient interface {
 	SubscribeOnSportsLines(ctx context.Context, opts ...grpc.CallOption) (SportsLinesService_SubscribeOnSportsLinesClient, error)
+	GetLatestLines(ctx context.Context, in *GetLatestLinesRequest, opts ...grpc.CallOption) (*GetLatestLinesResponse, error)
+	GetLineHistory(ctx context.Context, in *GetLineHistoryRequest, opts ...grpc.CallOption) (*GetLineHistoryResponse, error)
 }
 
 type sportsLinesServiceClient struct {
@@ -364,9 +1007,29 @@ func (x *sportsLinesServiceSubscribeOnSportsLinesClient) Recv() (*SubscribeOnSpo
 	return m, nil
 }
 
+func (c *sportsLinesServiceClient) GetLatestLines(ctx context.Context, in *GetLatestLinesRequest, opts ...grpc.CallOption) (*GetLatestLinesResponse, error) {
+	out := new(GetLatestLinesResponse)
+	err := c.cc.Invoke(ctx, "/SportsLinesService/GetLatestLines", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sportsLinesServiceClient) GetLineHistory(ctx context.Context, in *GetLineHistoryRequest, opts ...grpc.CallOption) (*GetLineHistoryResponse, error) {
+	out := new(GetLineHistoryResponse)
+	err := c.cc.Invoke(ctx, "/SportsLinesService/GetLineHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SportsLinesServiceServer is the server API for SportsLinesService service.
 type SportsLinesServiceServer interface {
 	SubscribeOnSportsLines(SportsLinesService_SubscribeOnSportsLinesServer) error
+	GetLatestLines(context.Context, *GetLatestLinesRequest) (*GetLatestLinesResponse, error)
+	GetLineHistory(context.Context, *GetLineHistoryRequest) (*GetLineHistoryResponse, error)
 }
 
 // UnimplementedSportsLinesServiceServer can be embedded to have forward compatible implementations.
@@ -376,6 +1039,12 @@ type UnimplementedSportsLinesServiceServer struct {
 func (*UnimplementedSportsLinesServiceServer) SubscribeOnSportsLines(SportsLinesService_SubscribeOnSportsLinesServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeOnSportsLines not implemented")
 }
+func (*UnimplementedSportsLinesServiceServer) GetLatestLines(context.Context, *GetLatestLinesRequest) (*GetLatestLinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLatestLines not implemented")
+}
+func (*UnimplementedSportsLinesServiceServer) GetLineHistory(context.Context, *GetLineHistoryRequest) (*GetLineHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLineHistory not implemented")
+}
 
 func RegisterSportsLinesServiceServer(s *grpc.Server, srv SportsLinesServiceServer) {
 	s.RegisterService(&_SportsLinesService_serviceDesc, srv)
@@ -407,10 +1076,55 @@ func (x *sportsLinesServiceSubscribeOnSportsLinesServer) Recv() (*SubscribeOnSpo
 	return m, nil
 }
 
+func _SportsLinesService_GetLatestLines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLatestLinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsLinesServiceServer).GetLatestLines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SportsLinesService/GetLatestLines",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsLinesServiceServer).GetLatestLines(ctx, req.(*GetLatestLinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SportsLinesService_GetLineHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLineHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsLinesServiceServer).GetLineHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SportsLinesService/GetLineHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsLinesServiceServer).GetLineHistory(ctx, req.(*GetLineHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _SportsLinesService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "SportsLinesService",
 	HandlerType: (*SportsLinesServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLatestLines",
+			Handler:    _SportsLinesService_GetLatestLines_Handler,
+		},
+		{
+			MethodName: "GetLineHistory",
+			Handler:    _SportsLinesService_GetLineHistory_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "SubscribeOnSportsLines",
@@ -0,0 +1,1459 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0-devel
+// 	protoc        v3.12.4
+// source: v2/sportslines.proto
+
+// Вторая версия API: спорты передаются не строками, а идентификаторами.
+// Первая версия (пакет без имени, /pb) продолжает обслуживаться тем же сервером
+
+package pbv2
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// Значение спорта совпадает с его id в таблице sports. Enum в proto3 открытый, поэтому спорты, добавленные
+// после выпуска клиента, приходят как числа, которых нет в его версии enum; их имена можно узнать через ListSports
+type Sport int32
+
+const (
+	Sport_SPORT_UNSPECIFIED Sport = 0
+	Sport_BASEBALL          Sport = 1
+	Sport_FOOTBALL          Sport = 2
+	Sport_SOCCER            Sport = 3
+)
+
+// Enum value maps for Sport.
+var (
+	Sport_name = map[int32]string{
+		0: "SPORT_UNSPECIFIED",
+		1: "BASEBALL",
+		2: "FOOTBALL",
+		3: "SOCCER",
+	}
+	Sport_value = map[string]int32{
+		"SPORT_UNSPECIFIED": 0,
+		"BASEBALL":          1,
+		"FOOTBALL":          2,
+		"SOCCER":            3,
+	}
+)
+
+func (x Sport) Enum() *Sport {
+	p := new(Sport)
+	*p = x
+	return p
+}
+
+func (x Sport) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Sport) Descriptor() protoreflect.EnumDescriptor {
+	return file_v2_sportslines_proto_enumTypes[0].Descriptor()
+}
+
+func (Sport) Type() protoreflect.EnumType {
+	return &file_v2_sportslines_proto_enumTypes[0]
+}
+
+func (x Sport) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Sport.Descriptor instead.
+func (Sport) EnumDescriptor() ([]byte, []int) {
+	return file_v2_sportslines_proto_rawDescGZIP(), []int{0}
+}
+
+// То же, что и SubscriptionMode в первой версии
+type SubscriptionMode int32
+
+const (
+	SubscriptionMode_EVERY_TICK   SubscriptionMode = 0 // каждый интервал, даже если дельты нулевые
+	SubscriptionMode_ON_CHANGE    SubscriptionMode = 1 // только если линия изменилась
+	SubscriptionMode_ON_THRESHOLD SubscriptionMode = 2 // только если линия изменилась больше, чем на threshold
+)
+
+// Enum value maps for SubscriptionMode.
+var (
+	SubscriptionMode_name = map[int32]string{
+		0: "EVERY_TICK",
+		1: "ON_CHANGE",
+		2: "ON_THRESHOLD",
+	}
+	SubscriptionMode_value = map[string]int32{
+		"EVERY_TICK":   0,
+		"ON_CHANGE":    1,
+		"ON_THRESHOLD": 2,
+	}
+)
+
+func (x SubscriptionMode) Enum() *SubscriptionMode {
+	p := new(SubscriptionMode)
+	*p = x
+	return p
+}
+
+func (x SubscriptionMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SubscriptionMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_v2_sportslines_proto_enumTypes[1].Descriptor()
+}
+
+func (SubscriptionMode) Type() protoreflect.EnumType {
+	return &file_v2_sportslines_proto_enumTypes[1]
+}
+
+func (x SubscriptionMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SubscriptionMode.Descriptor instead.
+func (SubscriptionMode) EnumDescriptor() ([]byte, []int) {
+	return file_v2_sportslines_proto_rawDescGZIP(), []int{1}
+}
+
+type SubscribeOnSportsLinesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Interval  uint32           `protobuf:"varint,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	Sports    []Sport          `protobuf:"varint,2,rep,packed,name=sports,proto3,enum=sportslines.v2.Sport" json:"sports,omitempty"`
+	Mode      SubscriptionMode `protobuf:"varint,3,opt,name=mode,proto3,enum=sportslines.v2.SubscriptionMode" json:"mode,omitempty"`
+	Threshold float32          `protobuf:"fixed32,4,opt,name=threshold,proto3" json:"threshold,omitempty"` // только для ON_THRESHOLD, должен быть больше 0
+	// resume_token из последнего полученного ответа оборвавшегося стрима, только в первом запросе нового стрима:
+	// сервер продолжит присылать дельты от тех же линий, что и раньше, а seq - с того же номера
+	ResumeToken string `protobuf:"bytes,5,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	// как часто в секундах присылать heartbeat, если других ответов в стриме не было; 0 - не присылать
+	HeartbeatInterval uint32 `protobuf:"varint,6,opt,name=heartbeat_interval,json=heartbeatInterval,proto3" json:"heartbeat_interval,omitempty"`
+}
+
+func (x *SubscribeOnSportsLinesRequest) Reset() {
+	*x = SubscribeOnSportsLinesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_sportslines_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeOnSportsLinesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeOnSportsLinesRequest) ProtoMessage() {}
+
+func (x *SubscribeOnSportsLinesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_sportslines_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeOnSportsLinesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeOnSportsLinesRequest) Descriptor() ([]byte, []int) {
+	return file_v2_sportslines_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubscribeOnSportsLinesRequest) GetInterval() uint32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *SubscribeOnSportsLinesRequest) GetSports() []Sport {
+	if x != nil {
+		return x.Sports
+	}
+	return nil
+}
+
+func (x *SubscribeOnSportsLinesRequest) GetMode() SubscriptionMode {
+	if x != nil {
+		return x.Mode
+	}
+	return SubscriptionMode_EVERY_TICK
+}
+
+func (x *SubscribeOnSportsLinesRequest) GetThreshold() float32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *SubscribeOnSportsLinesRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *SubscribeOnSportsLinesRequest) GetHeartbeatInterval() uint32 {
+	if x != nil {
+		return x.HeartbeatInterval
+	}
+	return 0
+}
+
+type SubscribeOnSportsLinesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lines       []*SportLine `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	Seq         uint64       `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`                                   // номер ответа в стриме, начиная с 1, продолжается при возобновлении стрима
+	ResumeToken string       `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"` // токен для возобновления стрима, пока он не истек
+	Heartbeat   *Heartbeat   `protobuf:"bytes,4,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`                        // только в heartbeat'ах, линий в них нет, а seq - номер последнего ответа с линиями
+}
+
+func (x *SubscribeOnSportsLinesResponse) Reset() {
+	*x = SubscribeOnSportsLinesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_sportslines_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeOnSportsLinesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeOnSportsLinesResponse) ProtoMessage() {}
+
+func (x *SubscribeOnSportsLinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_sportslines_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeOnSportsLinesResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeOnSportsLinesResponse) Descriptor() ([]byte, []int) {
+	return file_v2_sportslines_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubscribeOnSportsLinesResponse) GetLines() []*SportLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *SubscribeOnSportsLinesResponse) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SubscribeOnSportsLinesResponse) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *SubscribeOnSportsLinesResponse) GetHeartbeat() *Heartbeat {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return nil
+}
+
+// Heartbeat отличает тихий стрим (например, в режиме ON_CHANGE) от оборвавшегося
+type Heartbeat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=server_time,json=serverTime,proto3" json:"server_time,omitempty"`
+	Synced     bool                   `protobuf:"varint,2,opt,name=synced,proto3" json:"synced,omitempty"` // линии всех спортов стрима сейчас обновляются
+}
+
+func (x *Heartbeat) Reset() {
+	*x = Heartbeat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_sportslines_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Heartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Heartbeat) ProtoMessage() {}
+
+func (x *Heartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_sportslines_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Heartbeat.ProtoReflect.Descriptor instead.
+func (*Heartbeat) Descriptor() ([]byte, []int) {
+	return file_v2_sportslines_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Heartbeat) GetServerTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ServerTime
+	}
+	return nil
+}
+
+func (x *Heartbeat) GetSynced() bool {
+	if x != nil {
+		return x.Synced
+	}
+	return false
+}
+
+type SportLine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sport Sport   `protobuf:"varint,1,opt,name=sport,proto3,enum=sportslines.v2.Sport" json:"sport,omitempty"`
+	Line  float32 `protobuf:"fixed32,2,opt,name=line,proto3" json:"line,omitempty"`
+}
+
+func (x *SportLine) Reset() {
+	*x = SportLine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_sportslines_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SportLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SportLine) ProtoMessage() {}
+
+func (x *SportLine) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_sportslines_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SportLine.ProtoReflect.Descriptor instead.
+func (*SportLine) Descriptor() ([]byte, []int) {
+	return file_v2_sportslines_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SportLine) GetSport() Sport {
+	if x != nil {
+		return x.Sport
+	}
+	return Sport_SPORT_UNSPECIFIED
+}
+
+func (x *SportLine) GetLine() float32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+type GetLatestLinesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sports []Sport `protobuf:"varint,1,rep,packed,name=sports,proto3,enum=sportslines.v2.Sport" json:"sports,omitempty"`
+}
+
+func (x *GetLatestLinesRequest) Reset() {
+	*x = GetLatestLinesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_sportslines_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLatestLinesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestLinesRequest) ProtoMessage() {}
+
+func (x *GetLatestLinesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_sportslines_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestLinesRequest.ProtoReflect.Descriptor instead.
+func (*GetLatestLinesRequest) Descriptor() ([]byte, []int) {
+	return file_v2_sportslines_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetLatestLinesRequest) GetSports() []Sport {
+	if x != nil {
+		return x.Sports
+	}
+	return nil
+}
+
+type GetLatestLinesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lines []*SportLine `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"` // текущие линии в порядке запроса
+}
+
+func (x *GetLatestLinesResponse) Reset() {
+	*x = GetLatestLinesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_sportslines_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLatestLinesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestLinesResponse) ProtoMessage() {}
+
+func (x *GetLatestLinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_sportslines_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestLinesResponse.ProtoReflect.Descriptor instead.
+func (*GetLatestLinesResponse) Descriptor() ([]byte, []int) {
+	return file_v2_sportslines_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetLatestLinesResponse) GetLines() []*SportLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+// Линии спорта за промежуток [from, to) в хронологическом порядке, постранично
+type GetLineHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sport     Sport                  `protobuf:"varint,1,opt,name=sport,proto3,enum=sportslines.v2.Sport" json:"sport,omitempty"`
+	From      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`                            // если не указано, то с самой первой линии
+	To        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`                                // если не указано, то до текущего момента
+	PageSize  uint32                 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // не больше максимального кол-ва линий в ответе, заданного на сервере; 0 - максимальное
+	PageToken string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token из предыдущего ответа
+}
+
+func (x *GetLineHistoryRequest) Reset() {
+	*x = GetLineHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_sportslines_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLineHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLineHistoryRequest) ProtoMessage() {}
+
+func (x *GetLineHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_sportslines_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLineHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetLineHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_v2_sportslines_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetLineHistoryRequest) GetSport() Sport {
+	if x != nil {
+		return x.Sport
+	}
+	return Sport_SPORT_UNSPECIFIED
+}
+
+func (x *GetLineHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetLineHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetLineHistoryRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetLineHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetLineHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lines         []*HistoryLine `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	NextPageToken string         `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // пустой, если это последняя страница
+}
+
+func (x *GetLineHistoryResponse) Reset() {
+	*x = GetLineHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_sportslines_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLineHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLineHistoryResponse) ProtoMessage() {}
+
+func (x *GetLineHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_sportslines_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLineHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetLineHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_v2_sportslines_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetLineHistoryResponse) GetLines() []*HistoryLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *GetLineHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type HistoryLine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Line         float32                `protobuf:"fixed32,1,opt,name=line,proto3" json:"line,omitempty"`
+	FetchedAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+	ProviderTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=provider_time,json=providerTime,proto3" json:"provider_time,omitempty"` // если LinesProvider его прислал
+	Source       string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+}
+
+func (x *HistoryLine) Reset() {
+	*x = HistoryLine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_sportslines_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HistoryLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryLine) ProtoMessage() {}
+
+func (x *HistoryLine) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_sportslines_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryLine.ProtoReflect.Descriptor instead.
+func (*HistoryLine) Descriptor() ([]byte, []int) {
+	return file_v2_sportslines_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HistoryLine) GetLine() float32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *HistoryLine) GetFetchedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return nil
+}
+
+func (x *HistoryLine) GetProviderTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ProviderTime
+	}
+	return nil
+}
+
+func (x *HistoryLine) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type ListSportsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListSportsRequest) Reset() {
+	*x = ListSportsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_sportslines_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSportsRequest) ProtoMessage() {}
+
+func (x *ListSportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_sportslines_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSportsRequest.ProtoReflect.Descriptor instead.
+func (*ListSportsRequest) Descriptor() ([]byte, []int) {
+	return file_v2_sportslines_proto_rawDescGZIP(), []int{9}
+}
+
+type ListSportsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sports []*SportDescriptor `protobuf:"bytes,1,rep,name=sports,proto3" json:"sports,omitempty"`
+}
+
+func (x *ListSportsResponse) Reset() {
+	*x = ListSportsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_sportslines_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSportsResponse) ProtoMessage() {}
+
+func (x *ListSportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_sportslines_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSportsResponse.ProtoReflect.Descriptor instead.
+func (*ListSportsResponse) Descriptor() ([]byte, []int) {
+	return file_v2_sportslines_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListSportsResponse) GetSports() []*SportDescriptor {
+	if x != nil {
+		return x.Sports
+	}
+	return nil
+}
+
+type SportDescriptor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sport        Sport  `protobuf:"varint,1,opt,name=sport,proto3,enum=sportslines.v2.Sport" json:"sport,omitempty"`
+	Name         string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                      // имя спорта в первой версии API
+	PollInterval uint32 `protobuf:"varint,3,opt,name=poll_interval,json=pollInterval,proto3" json:"poll_interval,omitempty"` // как часто в секундах обновляются линии спорта
+}
+
+func (x *SportDescriptor) Reset() {
+	*x = SportDescriptor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_sportslines_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SportDescriptor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SportDescriptor) ProtoMessage() {}
+
+func (x *SportDescriptor) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_sportslines_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SportDescriptor.ProtoReflect.Descriptor instead.
+func (*SportDescriptor) Descriptor() ([]byte, []int) {
+	return file_v2_sportslines_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SportDescriptor) GetSport() Sport {
+	if x != nil {
+		return x.Sport
+	}
+	return Sport_SPORT_UNSPECIFIED
+}
+
+func (x *SportDescriptor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SportDescriptor) GetPollInterval() uint32 {
+	if x != nil {
+		return x.PollInterval
+	}
+	return 0
+}
+
+var File_v2_sportslines_proto protoreflect.FileDescriptor
+
+var file_v2_sportslines_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x76, 0x32, 0x2f, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x6c, 0x69, 0x6e, 0x65, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x6c, 0x69,
+	0x6e, 0x65, 0x73, 0x2e, 0x76, 0x32, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x90, 0x02, 0x0a, 0x1d, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x4f, 0x6e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x4c, 0x69, 0x6e,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x6c, 0x69,
+	0x6e, 0x65, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x06, 0x73, 0x70,
+	0x6f, 0x72, 0x74, 0x73, 0x12, 0x34, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x20, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x6c, 0x69, 0x6e, 0x65, 0x73,
+	0x2e, 0x76, 0x32, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75,
+	0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x68,
+	0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65,
+	0x61, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0xbf, 0x01, 0x0a, 0x1e, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4f, 0x6e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a,
+	0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73,
+	0x70, 0x6f, 0x72, 0x74, 0x73, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x70,
+	0x6f, 0x72, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71,
+	0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x37, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x6c,
+	0x69, 0x6e, 0x65, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61,
+	0x74, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x22, 0x60, 0x0a, 0x09,
+	0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x22, 0x4c,
+	0x0a, 0x09, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x73,
+	0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x73, 0x70, 0x6f,
+	0x72, 0x74, 0x73, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x70, 0x6f, 0x72,
+	0x74, 0x52, 0x05, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0x46, 0x0a, 0x15,
+	0x47, 0x65, 0x74, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x6c, 0x69,
+	0x6e, 0x65, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x06, 0x73, 0x70,
+	0x6f, 0x72, 0x74, 0x73, 0x22, 0x49, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x74, 0x65, 0x73,
+	0x74, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f,
+	0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x53,
+	0x70, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x22,
+	0xdc, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x05, 0x73, 0x70, 0x6f,
+	0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x05, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x02,
+	0x74, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x73,
+	0x0a, 0x16, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x6c, 0x69, 0x6e, 0x65, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x4c, 0x69, 0x6e, 0x65, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e,
+	0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x22, 0xb5, 0x01, 0x0a, 0x0b, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x4c,
+	0x69, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x02, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x66, 0x65, 0x74, 0x63, 0x68,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x66, 0x65, 0x74, 0x63, 0x68, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x3f, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x4d, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x06, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x6c,
+	0x69, 0x6e, 0x65, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x06, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22,
+	0x77, 0x0a, 0x0f, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x6f, 0x72, 0x12, 0x2b, 0x0a, 0x05, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x15, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x2e,
+	0x76, 0x32, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x05, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x6f, 0x6c, 0x6c, 0x5f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x6f, 0x6c, 0x6c,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x2a, 0x46, 0x0a, 0x05, 0x53, 0x70, 0x6f, 0x72,
+	0x74, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x42, 0x41, 0x53, 0x45,
+	0x42, 0x41, 0x4c, 0x4c, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x4f, 0x4f, 0x54, 0x42, 0x41,
+	0x4c, 0x4c, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x4f, 0x43, 0x43, 0x45, 0x52, 0x10, 0x03,
+	0x2a, 0x43, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x6f, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x45, 0x56, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x49,
+	0x43, 0x4b, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x4f, 0x4e, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47,
+	0x45, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x4f, 0x4e, 0x5f, 0x54, 0x48, 0x52, 0x45, 0x53, 0x48,
+	0x4f, 0x4c, 0x44, 0x10, 0x02, 0x32, 0xa8, 0x03, 0x0a, 0x12, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x4c, 0x69, 0x6e, 0x65, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x7b, 0x0a, 0x16,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4f, 0x6e, 0x53, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x2d, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x6c,
+	0x69, 0x6e, 0x65, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x4f, 0x6e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x6c, 0x69,
+	0x6e, 0x65, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x4f, 0x6e, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x5f, 0x0a, 0x0e, 0x47, 0x65, 0x74,
+	0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x73, 0x70,
+	0x6f, 0x72, 0x74, 0x73, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x47, 0x65, 0x74,
+	0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x26, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x6c, 0x69, 0x6e, 0x65, 0x73,
+	0x2e, 0x76, 0x32, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x6e,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x0e, 0x47, 0x65,
+	0x74, 0x4c, 0x69, 0x6e, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x25, 0x2e, 0x73,
+	0x70, 0x6f, 0x72, 0x74, 0x73, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x47, 0x65,
+	0x74, 0x4c, 0x69, 0x6e, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x6c, 0x69, 0x6e, 0x65,
+	0x73, 0x2e, 0x76, 0x32, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x0a, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x21, 0x2e, 0x73, 0x70, 0x6f, 0x72,
+	0x74, 0x73, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x73,
+	0x70, 0x6f, 0x72, 0x74, 0x73, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73,
+	0x6f, 0x66, 0x74, 0x70, 0x72, 0x6f, 0x2d, 0x6a, 0x75, 0x6e, 0x69, 0x6f, 0x72, 0x2d, 0x61, 0x73,
+	0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x62, 0x2f, 0x76, 0x32, 0x3b, 0x70,
+	0x62, 0x76, 0x32, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_v2_sportslines_proto_rawDescOnce sync.Once
+	file_v2_sportslines_proto_rawDescData = file_v2_sportslines_proto_rawDesc
+)
+
+func file_v2_sportslines_proto_rawDescGZIP() []byte {
+	file_v2_sportslines_proto_rawDescOnce.Do(func() {
+		file_v2_sportslines_proto_rawDescData = protoimpl.X.CompressGZIP(file_v2_sportslines_proto_rawDescData)
+	})
+	return file_v2_sportslines_proto_rawDescData
+}
+
+var file_v2_sportslines_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_v2_sportslines_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_v2_sportslines_proto_goTypes = []interface{}{
+	(Sport)(0),                             // 0: sportslines.v2.Sport
+	(SubscriptionMode)(0),                  // 1: sportslines.v2.SubscriptionMode
+	(*SubscribeOnSportsLinesRequest)(nil),  // 2: sportslines.v2.SubscribeOnSportsLinesRequest
+	(*SubscribeOnSportsLinesResponse)(nil), // 3: sportslines.v2.SubscribeOnSportsLinesResponse
+	(*Heartbeat)(nil),                      // 4: sportslines.v2.Heartbeat
+	(*SportLine)(nil),                      // 5: sportslines.v2.SportLine
+	(*GetLatestLinesRequest)(nil),          // 6: sportslines.v2.GetLatestLinesRequest
+	(*GetLatestLinesResponse)(nil),         // 7: sportslines.v2.GetLatestLinesResponse
+	(*GetLineHistoryRequest)(nil),          // 8: sportslines.v2.GetLineHistoryRequest
+	(*GetLineHistoryResponse)(nil),         // 9: sportslines.v2.GetLineHistoryResponse
+	(*HistoryLine)(nil),                    // 10: sportslines.v2.HistoryLine
+	(*ListSportsRequest)(nil),              // 11: sportslines.v2.ListSportsRequest
+	(*ListSportsResponse)(nil),             // 12: sportslines.v2.ListSportsResponse
+	(*SportDescriptor)(nil),                // 13: sportslines.v2.SportDescriptor
+	(*timestamppb.Timestamp)(nil),          // 14: google.protobuf.Timestamp
+}
+var file_v2_sportslines_proto_depIdxs = []int32{
+	0,  // 0: sportslines.v2.SubscribeOnSportsLinesRequest.sports:type_name -> sportslines.v2.Sport
+	1,  // 1: sportslines.v2.SubscribeOnSportsLinesRequest.mode:type_name -> sportslines.v2.SubscriptionMode
+	5,  // 2: sportslines.v2.SubscribeOnSportsLinesResponse.lines:type_name -> sportslines.v2.SportLine
+	4,  // 3: sportslines.v2.SubscribeOnSportsLinesResponse.heartbeat:type_name -> sportslines.v2.Heartbeat
+	14, // 4: sportslines.v2.Heartbeat.server_time:type_name -> google.protobuf.Timestamp
+	0,  // 5: sportslines.v2.SportLine.sport:type_name -> sportslines.v2.Sport
+	0,  // 6: sportslines.v2.GetLatestLinesRequest.sports:type_name -> sportslines.v2.Sport
+	5,  // 7: sportslines.v2.GetLatestLinesResponse.lines:type_name -> sportslines.v2.SportLine
+	0,  // 8: sportslines.v2.GetLineHistoryRequest.sport:type_name -> sportslines.v2.Sport
+	14, // 9: sportslines.v2.GetLineHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	14, // 10: sportslines.v2.GetLineHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	10, // 11: sportslines.v2.GetLineHistoryResponse.lines:type_name -> sportslines.v2.HistoryLine
+	14, // 12: sportslines.v2.HistoryLine.fetched_at:type_name -> google.protobuf.Timestamp
+	14, // 13: sportslines.v2.HistoryLine.provider_time:type_name -> google.protobuf.Timestamp
+	13, // 14: sportslines.v2.ListSportsResponse.sports:type_name -> sportslines.v2.SportDescriptor
+	0,  // 15: sportslines.v2.SportDescriptor.sport:type_name -> sportslines.v2.Sport
+	2,  // 16: sportslines.v2.SportsLinesService.SubscribeOnSportsLines:input_type -> sportslines.v2.SubscribeOnSportsLinesRequest
+	6,  // 17: sportslines.v2.SportsLinesService.GetLatestLines:input_type -> sportslines.v2.GetLatestLinesRequest
+	8,  // 18: sportslines.v2.SportsLinesService.GetLineHistory:input_type -> sportslines.v2.GetLineHistoryRequest
+	11, // 19: sportslines.v2.SportsLinesService.ListSports:input_type -> sportslines.v2.ListSportsRequest
+	3,  // 20: sportslines.v2.SportsLinesService.SubscribeOnSportsLines:output_type -> sportslines.v2.SubscribeOnSportsLinesResponse
+	7,  // 21: sportslines.v2.SportsLinesService.GetLatestLines:output_type -> sportslines.v2.GetLatestLinesResponse
+	9,  // 22: sportslines.v2.SportsLinesService.GetLineHistory:output_type -> sportslines.v2.GetLineHistoryResponse
+	12, // 23: sportslines.v2.SportsLinesService.ListSports:output_type -> sportslines.v2.ListSportsResponse
+	20, // [20:24] is the sub-list for method output_type
+	16, // [16:20] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_v2_sportslines_proto_init() }
+func file_v2_sportslines_proto_init() {
+	if File_v2_sportslines_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_v2_sportslines_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeOnSportsLinesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_sportslines_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeOnSportsLinesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_sportslines_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Heartbeat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_sportslines_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SportLine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_sportslines_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLatestLinesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_sportslines_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLatestLinesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_sportslines_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLineHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_sportslines_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLineHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_sportslines_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoryLine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_sportslines_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSportsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_sportslines_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSportsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_sportslines_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SportDescriptor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_v2_sportslines_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_v2_sportslines_proto_goTypes,
+		DependencyIndexes: file_v2_sportslines_proto_depIdxs,
+		EnumInfos:         file_v2_sportslines_proto_enumTypes,
+		MessageInfos:      file_v2_sportslines_proto_msgTypes,
+	}.Build()
+	File_v2_sportslines_proto = out.File
+	file_v2_sportslines_proto_rawDesc = nil
+	file_v2_sportslines_proto_goTypes = nil
+	file_v2_sportslines_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// SportsLinesServiceClient is the client API for SportsLinesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SportsLinesServiceClient interface {
+	SubscribeOnSportsLines(ctx context.Context, opts ...grpc.CallOption) (SportsLinesService_SubscribeOnSportsLinesClient, error)
+	GetLatestLines(ctx context.Context, in *GetLatestLinesRequest, opts ...grpc.CallOption) (*GetLatestLinesResponse, error)
+	GetLineHistory(ctx context.Context, in *GetLineHistoryRequest, opts ...grpc.CallOption) (*GetLineHistoryResponse, error)
+	ListSports(ctx context.Context, in *ListSportsRequest, opts ...grpc.CallOption) (*ListSportsResponse, error)
+}
+
+type sportsLinesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSportsLinesServiceClient(cc grpc.ClientConnInterface) SportsLinesServiceClient {
+	return &sportsLinesServiceClient{cc}
+}
+
+func (c *sportsLinesServiceClient) SubscribeOnSportsLines(ctx context.Context, opts ...grpc.CallOption) (SportsLinesService_SubscribeOnSportsLinesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SportsLinesService_serviceDesc.Streams[0], "/sportslines.v2.SportsLinesService/SubscribeOnSportsLines", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sportsLinesServiceSubscribeOnSportsLinesClient{stream}
+	return x, nil
+}
+
+type SportsLinesService_SubscribeOnSportsLinesClient interface {
+	Send(*SubscribeOnSportsLinesRequest) error
+	Recv() (*SubscribeOnSportsLinesResponse, error)
+	grpc.ClientStream
+}
+
+type sportsLinesServiceSubscribeOnSportsLinesClient struct {
+	grpc.ClientStream
+}
+
+func (x *sportsLinesServiceSubscribeOnSportsLinesClient) Send(m *SubscribeOnSportsLinesRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *sportsLinesServiceSubscribeOnSportsLinesClient) Recv() (*SubscribeOnSportsLinesResponse, error) {
+	m := new(SubscribeOnSportsLinesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sportsLinesServiceClient) GetLatestLines(ctx context.Context, in *GetLatestLinesRequest, opts ...grpc.CallOption) (*GetLatestLinesResponse, error) {
+	out := new(GetLatestLinesResponse)
+	err := c.cc.Invoke(ctx, "/sportslines.v2.SportsLinesService/GetLatestLines", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sportsLinesServiceClient) GetLineHistory(ctx context.Context, in *GetLineHistoryRequest, opts ...grpc.CallOption) (*GetLineHistoryResponse, error) {
+	out := new(GetLineHistoryResponse)
+	err := c.cc.Invoke(ctx, "/sportslines.v2.SportsLinesService/GetLineHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sportsLinesServiceClient) ListSports(ctx context.Context, in *ListSportsRequest, opts ...grpc.CallOption) (*ListSportsResponse, error) {
+	out := new(ListSportsResponse)
+	err := c.cc.Invoke(ctx, "/sportslines.v2.SportsLinesService/ListSports", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SportsLinesServiceServer is the server API for SportsLinesService service.
+type SportsLinesServiceServer interface {
+	SubscribeOnSportsLines(SportsLinesService_SubscribeOnSportsLinesServer) error
+	GetLatestLines(context.Context, *GetLatestLinesRequest) (*GetLatestLinesResponse, error)
+	GetLineHistory(context.Context, *GetLineHistoryRequest) (*GetLineHistoryResponse, error)
+	ListSports(context.Context, *ListSportsRequest) (*ListSportsResponse, error)
+}
+
+// UnimplementedSportsLinesServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedSportsLinesServiceServer struct {
+}
+
+func (*UnimplementedSportsLinesServiceServer) SubscribeOnSportsLines(SportsLinesService_SubscribeOnSportsLinesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeOnSportsLines not implemented")
+}
+func (*UnimplementedSportsLinesServiceServer) GetLatestLines(context.Context, *GetLatestLinesRequest) (*GetLatestLinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLatestLines not implemented")
+}
+func (*UnimplementedSportsLinesServiceServer) GetLineHistory(context.Context, *GetLineHistoryRequest) (*GetLineHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLineHistory not implemented")
+}
+func (*UnimplementedSportsLinesServiceServer) ListSports(context.Context, *ListSportsRequest) (*ListSportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSports not implemented")
+}
+
+func RegisterSportsLinesServiceServer(s *grpc.Server, srv SportsLinesServiceServer) {
+	s.RegisterService(&_SportsLinesService_serviceDesc, srv)
+}
+
+func _SportsLinesService_SubscribeOnSportsLines_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SportsLinesServiceServer).SubscribeOnSportsLines(&sportsLinesServiceSubscribeOnSportsLinesServer{stream})
+}
+
+type SportsLinesService_SubscribeOnSportsLinesServer interface {
+	Send(*SubscribeOnSportsLinesResponse) error
+	Recv() (*SubscribeOnSportsLinesRequest, error)
+	grpc.ServerStream
+}
+
+type sportsLinesServiceSubscribeOnSportsLinesServer struct {
+	grpc.ServerStream
+}
+
+func (x *sportsLinesServiceSubscribeOnSportsLinesServer) Send(m *SubscribeOnSportsLinesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *sportsLinesServiceSubscribeOnSportsLinesServer) Recv() (*SubscribeOnSportsLinesRequest, error) {
+	m := new(SubscribeOnSportsLinesRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _SportsLinesService_GetLatestLines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLatestLinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsLinesServiceServer).GetLatestLines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sportslines.v2.SportsLinesService/GetLatestLines",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsLinesServiceServer).GetLatestLines(ctx, req.(*GetLatestLinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SportsLinesService_GetLineHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLineHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsLinesServiceServer).GetLineHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sportslines.v2.SportsLinesService/GetLineHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsLinesServiceServer).GetLineHistory(ctx, req.(*GetLineHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SportsLinesService_ListSports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsLinesServiceServer).ListSports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sportslines.v2.SportsLinesService/ListSports",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsLinesServiceServer).ListSports(ctx, req.(*ListSportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SportsLinesService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "sportslines.v2.SportsLinesService",
+	HandlerType: (*SportsLinesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLatestLines",
+			Handler:    _SportsLinesService_GetLatestLines_Handler,
+		},
+		{
+			MethodName: "GetLineHistory",
+			Handler:    _SportsLinesService_GetLineHistory_Handler,
+		},
+		{
+			MethodName: "ListSports",
+			Handler:    _SportsLinesService_ListSports_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeOnSportsLines",
+			Handler:       _SportsLinesService_SubscribeOnSportsLines_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "v2/sportslines.proto",
+}
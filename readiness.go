@@ -0,0 +1,153 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// имя сервиса в grpc.health.v1.Health, статус которого отражает готовность линий одного спорта: SportsLinesService/<sport>
+const sportHealthServicePrefix = "SportsLinesService/"
+
+// readinessChecker - общие проверки готовности для /ready и grpc.health.v1.Health: хранилище доступно,
+// а линии каждого спорта успешно записывались в него не позже, чем staleness интервалов опроса назад
+type readinessChecker struct {
+	ping     func() error
+	sv       *supervisor
+	registry *sportRegistry
+	updates  *updateTracker
+	// во сколько раз время с последнего обновления линий спорта может превышать его интервал опроса
+	staleness uint
+	// для спортов, линии которых еще ни разу не обновлялись, возраст отсчитывается от старта сервиса
+	startedAt time.Time
+	// сервисы в grpc.health.v1.Health, статус которых совпадает с общим
+	services []string
+}
+
+// ReadinessReport - тело ответа /ready
+type ReadinessReport struct {
+	Ready   bool                      `json:"ready"`
+	Storage string                    `json:"storage"` // "ok" или причина недоступности
+	Sports  map[string]SportReadiness `json:"sports"`
+}
+
+type SportReadiness struct {
+	Ready      bool       `json:"ready"`
+	Worker     string     `json:"worker"`      // состояние воркера спорта
+	LastUpdate *time.Time `json:"last_update"` // время последней успешной записи линий, null - еще не было
+	// сколько секунд прошло с последнего обновления (или старта сервиса) и сколько может пройти
+	Age    float64 `json:"age_seconds"`
+	MaxAge float64 `json:"max_age_seconds"`
+}
+
+// Report проверяет готовность сервиса и каждого спорта
+func (c *readinessChecker) Report() ReadinessReport {
+	report := ReadinessReport{
+		Ready:   true,
+		Storage: "ok",
+		Sports:  make(map[string]SportReadiness),
+	}
+
+	if err := c.checkStorage(); err != nil {
+		report.Ready = false
+		report.Storage = err.Error()
+	}
+
+	now := time.Now()
+	for _, sport := range c.registry.All() {
+		sportReport := SportReadiness{
+			Worker: "not started",
+			MaxAge: (time.Duration(c.staleness*sport.PollInterval) * time.Second).Seconds(),
+		}
+
+		if status, found := c.sv.Status(sport.Name); found {
+			sportReport.Worker = status.State.String()
+		}
+
+		since := c.startedAt
+		if last, found := c.updates.Get(sport.Name); found {
+			since = last
+			sportReport.LastUpdate = &last
+		}
+		sportReport.Age = now.Sub(since).Seconds()
+		sportReport.Ready = sportReport.LastUpdate != nil && sportReport.Age <= sportReport.MaxAge
+
+		if !sportReport.Ready {
+			report.Ready = false
+		}
+		report.Sports[sport.Name] = sportReport
+	}
+
+	return report
+}
+
+// Err возвращает причину, по которой сервис не готов, или nil
+func (r ReadinessReport) Err() error {
+	if r.Ready {
+		return nil
+	}
+	if r.Storage != "ok" {
+		return errors.New(r.Storage)
+	}
+
+	var stale []string
+	for name, sport := range r.Sports {
+		if !sport.Ready {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	return errors.New("The lines of the following sports are stale: " + strings.Join(stale, ", "))
+}
+
+func (c *readinessChecker) checkStorage() error {
+	// правильно ли я понимаю, что именно в этом заключается проверка соединения с хранилищем?
+	// т.е. в использовании Ping()
+	if err := c.ping(); err != nil {
+		return errors.New("Failed to connect to the storage")
+	}
+
+	if !isSynced {
+		return errors.New("The storage is not synced with the Lines Provider")
+	}
+
+	return nil
+}
+
+// WatchHealth раз в interval обновляет статусы hs: общий ("" и services) и каждого спорта
+func (c *readinessChecker) WatchHealth(hs *health.Server, interval time.Duration, abort <-chan struct{}) {
+	for {
+		c.updateHealth(hs)
+
+		select {
+		case <-abort:
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (c *readinessChecker) updateHealth(hs *health.Server) {
+	report := c.Report()
+
+	overall := healthpb.HealthCheckResponse_NOT_SERVING
+	if report.Ready {
+		overall = healthpb.HealthCheckResponse_SERVING
+	}
+	hs.SetServingStatus("", overall)
+	for _, service := range c.services {
+		hs.SetServingStatus(service, overall)
+	}
+
+	for name, sport := range report.Sports {
+		sportStatus := healthpb.HealthCheckResponse_NOT_SERVING
+		if report.Storage == "ok" && sport.Ready {
+			sportStatus = healthpb.HealthCheckResponse_SERVING
+		}
+		hs.SetServingStatus(sportHealthServicePrefix+name, sportStatus)
+	}
+}
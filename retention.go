@@ -0,0 +1,84 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/softpro-junior-assignment/services"
+)
+
+// ключ политики хранения, которая применяется к спортам, для которых своя политика не задана
+const DefaultRetentionKey = "*"
+
+type RetentionConfig struct {
+	RawDays  uint `json:"raw_days"`  // сколько дней хранить линии как есть, потом они сжимаются до поминутных; 0 - не сжимать
+	KeepDays uint `json:"keep_days"` // через сколько дней удалять любые линии; 0 - не удалять
+}
+
+// retentionJob периодически сжимает и удаляет старые линии согласно политикам хранения спортов
+type retentionJob struct {
+	lines    services.LinesDB
+	registry *sportRegistry
+	policies map[string]RetentionConfig
+	interval time.Duration
+}
+
+func newRetentionJob(lines services.LinesDB, registry *sportRegistry, policies map[string]RetentionConfig, interval time.Duration) *retentionJob {
+	return &retentionJob{
+		lines:    lines,
+		registry: registry,
+		policies: policies,
+		interval: interval,
+	}
+}
+
+// Run запускает сжатие раз в interval, пока не закрыт abort, и закрывает done по завершении
+func (j *retentionJob) Run(abort <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	for {
+		select {
+		case <-abort:
+			return
+		case <-time.After(j.interval):
+		}
+
+		j.runOnce(time.Now())
+	}
+}
+
+func (j *retentionJob) runOnce(now time.Time) {
+	start := time.Now()
+	retentionRuns.Inc()
+
+	for _, name := range j.registry.Names() {
+		policy, found := j.policies[name]
+		if !found {
+			policy = j.policies[DefaultRetentionKey]
+		}
+
+		if policy.RawDays != 0 {
+			replaced, inserted, err := j.lines.Downsample(name, now.AddDate(0, 0, -int(policy.RawDays)))
+			if err != nil {
+				retentionErrors.Inc()
+				log.Printf("Failed to downsample lines of the %v sport: %v\n", name, err)
+				continue
+			}
+			retentionRows.WithLabelValues("downsampled").Add(float64(replaced))
+			retentionRows.WithLabelValues("inserted").Add(float64(inserted))
+		}
+
+		if policy.KeepDays != 0 {
+			deleted, err := j.lines.DeleteBefore(name, now.AddDate(0, 0, -int(policy.KeepDays)))
+			if err != nil {
+				retentionErrors.Inc()
+				log.Printf("Failed to delete expired lines of the %v sport: %v\n", name, err)
+				continue
+			}
+			retentionRows.WithLabelValues("deleted").Add(float64(deleted))
+		}
+	}
+
+	retentionLastRunDuration.Set(time.Since(start).Seconds())
+	retentionLastRun.Set(float64(now.Unix()))
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Poll - результат одного опроса LinesProvider'а
+type Poll struct {
+	Lines        []float64
+	FetchedAt    time.Time
+	ProviderTime *time.Time // если LinesProvider его прислал
+	Source       string     // идентификатор LinesProvider'а, от которого пришел ответ
+}
+
+// Line - строка таблицы линий спорта
+type Line struct {
+	ID           uint
+	Line         float32
+	FetchedAt    time.Time
+	ProviderTime *time.Time
+	Source       string
+}
+
+// ErrNoLines - в таблице линий спорта еще нет ни одной линии (например, спорт только что добавлен)
+var ErrNoLines = errors.New("No lines have been stored for the sport yet")
+
+// DownsampledSource - source сжатых (поминутных) линий
+const DownsampledSource = "downsampled"
+
+type LinesDB interface {
+	// Insert атомарно, одним запросом, записывает все линии одного опроса LinesProvider'а
+	Insert(sportName string, poll Poll) error
+	// Latest возвращает последнюю записанную линию спорта или ErrNoLines, если линий еще нет
+	Latest(sportName string) (*Line, error)
+	// History возвращает не больше limit линий спорта, полученных в промежутке [from, to), в хронологическом порядке,
+	// начиная с линии, следующей за after (если after не nil)
+	History(sportName string, from, to time.Time, after *HistoryCursor, limit uint) ([]Line, error)
+	// Downsample атомарно заменяет несжатые линии, полученные до before, на средние значения за каждую минуту.
+	// Возвращает кол-во замененных линий и кол-во добавленных поминутных
+	Downsample(sportName string, before time.Time) (replaced, inserted int64, err error)
+	// DeleteBefore удаляет все линии, полученные до before, и возвращает их кол-во
+	DeleteBefore(sportName string, before time.Time) (int64, error)
+}
+
+// HistoryCursor - позиция последней линии предыдущей страницы истории
+type HistoryCursor struct {
+	FetchedAt time.Time
+	ID        uint
+}
+
+type linesGorm struct {
+	db *gorm.DB
+}
+
+func NewLinesService(db *gorm.DB) LinesDB {
+	return &linesGorm{db: db}
+}
+
+func (lg *linesGorm) Insert(sportName string, poll Poll) error {
+	if len(poll.Lines) == 0 {
+		return nil
+	}
+
+	// имена таблиц не параметризуются плейсхолдерами (https://github.com/golang/go/issues/18478),
+	// от sql инъекции здесь спасает проверка имен спортов в ValidSportName при их регистрации
+	query := `INSERT INTO "` + sportName + `s" ("line", "fetched_at", "provider_time", "source") VALUES ` +
+		strings.TrimSuffix(strings.Repeat(`(?, ?, ?, ?),`, len(poll.Lines)), ",")
+	args := make([]interface{}, 0, 4*len(poll.Lines))
+	for _, line := range poll.Lines {
+		args = append(args, line, poll.FetchedAt, poll.ProviderTime, poll.Source)
+	}
+
+	tx := lg.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := tx.Exec(query, args...).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (lg *linesGorm) Latest(sportName string) (*Line, error) {
+	var line Line
+	err := lg.db.Raw(`SELECT "id", "line", "fetched_at", "provider_time", "source" FROM "` + sportName + `s" ORDER BY "fetched_at" DESC, "id" DESC LIMIT 1`).Scan(&line).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, ErrNoLines
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &line, nil
+}
+
+func (lg *linesGorm) History(sportName string, from, to time.Time, after *HistoryCursor, limit uint) ([]Line, error) {
+	query := `SELECT "id", "line", "fetched_at", "provider_time", "source" FROM "` + sportName + `s" ` +
+		`WHERE "fetched_at" >= ? AND "fetched_at" < ?`
+	args := []interface{}{from, to}
+	if after != nil {
+		query += ` AND ("fetched_at", "id") > (?, ?)`
+		args = append(args, after.FetchedAt, after.ID)
+	}
+	query += ` ORDER BY "fetched_at", "id" LIMIT ?`
+	args = append(args, limit)
+
+	var lines []Line
+	err := lg.db.Raw(query, args...).Scan(&lines).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	return lines, err
+}
+
+func (lg *linesGorm) Downsample(sportName string, before time.Time) (replaced, inserted int64, err error) {
+	// before округляется до минуты, чтобы минута не оказалась сжата частями за несколько запусков
+	var res struct {
+		Replaced int64
+		Inserted int64
+	}
+	err = lg.db.Raw(`WITH "replaced" AS (`+
+		`DELETE FROM "`+sportName+`s" WHERE "fetched_at" < ? AND "source" <> ? RETURNING "fetched_at", "line"`+
+		`), "inserted" AS (`+
+		`INSERT INTO "`+sportName+`s" ("line", "fetched_at", "source") `+
+		`SELECT avg("line"), date_trunc('minute', "fetched_at"), ? FROM "replaced" GROUP BY 2 RETURNING 1`+
+		`) SELECT (SELECT count(*) FROM "replaced") AS "replaced", (SELECT count(*) FROM "inserted") AS "inserted"`,
+		before.Truncate(time.Minute), DownsampledSource, DownsampledSource).Scan(&res).Error
+	return res.Replaced, res.Inserted, err
+}
+
+func (lg *linesGorm) DeleteBefore(sportName string, before time.Time) (int64, error) {
+	res := lg.db.Exec(`DELETE FROM "`+sportName+`s" WHERE "fetched_at" < ?`, before)
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ключ pg_advisory_xact_lock, чтобы несколько экземпляров сервиса не применяли миграции одновременно
+const migrationsLockKey = 7142020
+
+type migration struct {
+	version uint
+	name    string
+	up      func(tx *gorm.DB) error
+	down    func(tx *gorm.DB) error
+}
+
+// migrations применяются строго по порядку версий, уже выложенные миграции не меняются, только добавляются новые
+var migrations = []migration{
+	{
+		version: 1,
+		name:    "create_sports",
+		up: func(tx *gorm.DB) error {
+			err := tx.Exec(`CREATE TABLE IF NOT EXISTS "sports" (` +
+				`"id" serial PRIMARY KEY, ` +
+				`"name" text NOT NULL UNIQUE, ` +
+				`"poll_interval" integer NOT NULL)`).Error
+			if err != nil {
+				return err
+			}
+
+			// спорты, которые были доступны изначально, их таблицы линий могли быть созданы еще -setschema
+			for _, name := range []string{"baseball", "football", "soccer"} {
+				err = tx.Exec(`INSERT INTO "sports" ("name", "poll_interval") VALUES (?, 1) ON CONFLICT ("name") DO NOTHING`, name).Error
+				if err != nil {
+					return err
+				}
+
+				err = tx.Exec(`CREATE TABLE IF NOT EXISTS "` + name + `s" ("id" serial PRIMARY KEY, "line" real)`).Error
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		down: func(tx *gorm.DB) error {
+			names, err := sportNames(tx)
+			if err != nil {
+				return err
+			}
+
+			for _, name := range names {
+				err = tx.Exec(`DROP TABLE IF EXISTS "` + name + `s"`).Error
+				if err != nil {
+					return err
+				}
+			}
+			return tx.Exec(`DROP TABLE "sports"`).Error
+		},
+	},
+	{
+		version: 2,
+		name:    "add_lines_provenance",
+		up: func(tx *gorm.DB) error {
+			return forEachLinesTable(tx, `ALTER TABLE IF EXISTS %v `+
+				`ADD COLUMN IF NOT EXISTS "fetched_at" timestamptz NOT NULL DEFAULT now(), `+
+				`ADD COLUMN IF NOT EXISTS "provider_time" timestamptz, `+
+				`ADD COLUMN IF NOT EXISTS "source" text NOT NULL DEFAULT ''`)
+		},
+		down: func(tx *gorm.DB) error {
+			return forEachLinesTable(tx, `ALTER TABLE IF EXISTS %v `+
+				`DROP COLUMN "fetched_at", `+
+				`DROP COLUMN "provider_time", `+
+				`DROP COLUMN "source"`)
+		},
+	},
+	{
+		version: 3,
+		name:    "index_lines_fetched_at",
+		up: func(tx *gorm.DB) error {
+			return forEachSport(tx, func(name string) error {
+				return tx.Exec(`CREATE INDEX IF NOT EXISTS "` + name + `s_fetched_at_idx" ON "` + name + `s" ("fetched_at", "id")`).Error
+			})
+		},
+		down: func(tx *gorm.DB) error {
+			return forEachSport(tx, func(name string) error {
+				return tx.Exec(`DROP INDEX IF EXISTS "` + name + `s_fetched_at_idx"`).Error
+			})
+		},
+	},
+}
+
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	AppliedAt *time.Time
+}
+
+// MigrateUp применяет все неприменённые миграции, каждую в своей транзакции
+func (s *Services) MigrateUp() error {
+	if err := createMigrationsTable(s.DB); err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		err := inMigrationTx(s.DB, func(tx *gorm.DB, applied map[uint]bool) error {
+			if applied[m.version] {
+				return nil
+			}
+
+			log.Printf("Applying migration %d (%v)\n", m.version, m.name)
+			if err := m.up(tx); err != nil {
+				return err
+			}
+			return tx.Exec(`INSERT INTO "schema_migrations" ("version", "name") VALUES (?, ?)`, m.version, m.name).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MigrateDown откатывает последнюю применённую миграцию
+func (s *Services) MigrateDown() error {
+	if err := createMigrationsTable(s.DB); err != nil {
+		return err
+	}
+
+	return inMigrationTx(s.DB, func(tx *gorm.DB, applied map[uint]bool) error {
+		for i := len(migrations) - 1; i >= 0; i-- {
+			m := migrations[i]
+			if !applied[m.version] {
+				continue
+			}
+
+			log.Printf("Reverting migration %d (%v)\n", m.version, m.name)
+			if err := m.down(tx); err != nil {
+				return err
+			}
+			return tx.Exec(`DELETE FROM "schema_migrations" WHERE "version" = ?`, m.version).Error
+		}
+
+		return errors.New("There are no applied migrations")
+	})
+}
+
+func (s *Services) MigrationsStatus() ([]MigrationStatus, error) {
+	if err := createMigrationsTable(s.DB); err != nil {
+		return nil, err
+	}
+
+	var applied []MigrationStatus
+	err := s.DB.Raw(`SELECT "version", "name", "applied_at" FROM "schema_migrations"`).Scan(&applied).Error
+	if err != nil {
+		return nil, err
+	}
+
+	appliedAt := make(map[uint]*time.Time, len(applied))
+	for _, m := range applied {
+		appliedAt[m.Version] = m.AppliedAt
+	}
+
+	statuses := make([]MigrationStatus, len(migrations))
+	for i, m := range migrations {
+		statuses[i] = MigrationStatus{Version: m.version, Name: m.name, AppliedAt: appliedAt[m.version]}
+	}
+	return statuses, nil
+}
+
+// PendingMigrations возвращает кол-во неприменённых миграций
+func (s *Services) PendingMigrations() (int, error) {
+	statuses, err := s.MigrationsStatus()
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+func createMigrationsTable(db *gorm.DB) error {
+	return db.Exec(`CREATE TABLE IF NOT EXISTS "schema_migrations" (` +
+		`"version" integer PRIMARY KEY, ` +
+		`"name" text NOT NULL, ` +
+		`"applied_at" timestamptz NOT NULL DEFAULT now())`).Error
+}
+
+// inMigrationTx выполняет f в транзакции под advisory lock, передавая в f уже применённые версии
+func inMigrationTx(db *gorm.DB, f func(tx *gorm.DB, applied map[uint]bool) error) error {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	err := tx.Exec(`SELECT pg_advisory_xact_lock(?)`, migrationsLockKey).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var versions []uint
+	err = tx.Raw(`SELECT "version" FROM "schema_migrations"`).Pluck("version", &versions).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	applied := make(map[uint]bool, len(versions))
+	for _, v := range versions {
+		applied[v] = true
+	}
+
+	if err := f(tx, applied); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// forEachLinesTable выполняет query для таблицы линий каждого спорта, вместо %v подставляется имя таблицы
+func forEachLinesTable(tx *gorm.DB, query string) error {
+	return forEachSport(tx, func(name string) error {
+		return tx.Exec(fmt.Sprintf(query, `"`+name+`s"`)).Error
+	})
+}
+
+// forEachSport вызывает f для каждого спорта, у которого уже есть таблица линий
+func forEachSport(tx *gorm.DB, f func(name string) error) error {
+	names, err := sportNames(tx)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if err := f(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sportNames(db *gorm.DB) ([]string, error) {
+	var names []string
+	err := db.Raw(`SELECT "name" FROM "sports" WHERE to_regclass(quote_ident("name" || 's')) IS NOT NULL`).Pluck("name", &names).Error
+	return names, err
+}
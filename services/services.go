@@ -13,6 +13,8 @@ type ServicesConfig func(*Services) error
 
 type Services struct {
 	DB      *gorm.DB
+	Lines   LinesDB
+	Sports  SportsDB
 	logFile *os.File
 }
 
@@ -70,11 +72,16 @@ func WithLogMode(mode bool) ServicesConfig {
 	}
 }
 
-func WithSetSchema(mode bool) ServicesConfig {
+func WithLines() ServicesConfig {
 	return func(s *Services) error {
-		if mode {
-			return setSchema(s.DB)
-		}
+		s.Lines = NewLinesService(s.DB)
+		return nil
+	}
+}
+
+func WithSports() ServicesConfig {
+	return func(s *Services) error {
+		s.Sports = NewSportsService(s.DB)
 		return nil
 	}
 }
@@ -93,41 +100,3 @@ func (s *Services) CloseStorage() {
 		log.Println(err)
 	}
 }
-
-func setSchema(db *gorm.DB) error {
-	err := db.Debug().Exec("DROP SCHEMA public CASCADE").Error
-	if err != nil {
-		return err
-	}
-
-	err = db.Debug().Exec("CREATE SCHEMA public").Error
-	if err != nil {
-		return err
-	}
-
-	err = db.Debug().CreateTable(
-		&Baseball{},
-		&Football{},
-		&Soccer{},
-	).Error
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-type Baseball struct {
-	ID   *uint `gorm:"primary_key"`
-	Line *float32
-}
-
-type Football struct {
-	ID   *uint `gorm:"primary_key"`
-	Line *float32
-}
-
-type Soccer struct {
-	ID   *uint `gorm:"primary_key"`
-	Line *float32
-}
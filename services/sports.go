@@ -0,0 +1,94 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/jinzhu/gorm"
+)
+
+// имя спорта подставляется в имя таблицы, поэтому допустимы только такие имена
+var sportNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]{0,61}$`)
+
+var ErrInvalidSportName = errors.New("A sport name must consist of lowercase latin letters, digits and underscores and start with a letter")
+
+func ValidSportName(name string) bool {
+	return sportNameRegexp.MatchString(name)
+}
+
+type Sport struct {
+	ID           uint   `gorm:"primary_key"`
+	Name         string `gorm:"unique;not null"`
+	PollInterval uint   `gorm:"not null"`
+}
+
+type SportsDB interface {
+	All() ([]Sport, error)
+	// Upsert добавляет спорт (или обновляет его интервал) и создает для него таблицу линий, если ее еще нет
+	Upsert(name string, pollInterval uint) (*Sport, error)
+	// CreateLinesTable создает таблицу линий спорта, если ее еще нет
+	CreateLinesTable(name string) error
+}
+
+type sportsGorm struct {
+	db *gorm.DB
+}
+
+func NewSportsService(db *gorm.DB) SportsDB {
+	return &sportsGorm{db: db}
+}
+
+func (sg *sportsGorm) All() ([]Sport, error) {
+	var sports []Sport
+	err := sg.db.Order("id").Find(&sports).Error
+	return sports, err
+}
+
+func (sg *sportsGorm) Upsert(name string, pollInterval uint) (*Sport, error) {
+	if !ValidSportName(name) {
+		return nil, ErrInvalidSportName
+	}
+
+	tx := sg.db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	var sport Sport
+	err := tx.Raw(`INSERT INTO "sports" ("name", "poll_interval") VALUES (?, ?) `+
+		`ON CONFLICT ("name") DO UPDATE SET "poll_interval" = EXCLUDED."poll_interval" `+
+		`RETURNING "id", "name", "poll_interval"`, name, pollInterval).Scan(&sport).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := createLinesTable(tx, name); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return &sport, tx.Commit().Error
+}
+
+func (sg *sportsGorm) CreateLinesTable(name string) error {
+	if !ValidSportName(name) {
+		return ErrInvalidSportName
+	}
+	return createLinesTable(sg.db, name)
+}
+
+// createLinesTable создает таблицу линий в актуальной (после всех миграций) схеме
+func createLinesTable(db *gorm.DB, name string) error {
+	err := db.Exec(`CREATE TABLE IF NOT EXISTS "` + name + `s" (` +
+		`"id" serial PRIMARY KEY, ` +
+		`"line" real, ` +
+		`"fetched_at" timestamptz NOT NULL DEFAULT now(), ` +
+		`"provider_time" timestamptz, ` +
+		`"source" text NOT NULL DEFAULT '')`).Error
+	if err != nil {
+		return err
+	}
+
+	return db.Exec(`CREATE INDEX IF NOT EXISTS "` + name + `s_fetched_at_idx" ON "` + name + `s" ("fetched_at", "id")`).Error
+}
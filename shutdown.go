@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"google.golang.org/grpc"
+)
+
+// shutdown по порядку останавливает gRPC сервер (стримы перед этим получают финальный статус через closeStreams),
+// HTTP сервер и воркеры (stopWorkers останавливает, waitWorkers дожидается их завершения), укладываясь в дедлайн ctx.
+// Хранилище закрывается вызывающей стороной уже после.
+func shutdown(ctx context.Context, grpcServer *grpc.Server, closeStreams func(), httpServer *http.Server, stopWorkers, waitWorkers func()) {
+	log.Println("Draining gRPC streams...")
+	closeStreams()
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		log.Println("Failed to drain gRPC streams in time, closing them forcibly")
+		grpcServer.Stop()
+	}
+
+	log.Println("Shutting down the HTTP server...")
+	if err := httpServer.Shutdown(ctx); err != nil {
+		log.Printf("Failed to shut down the HTTP server gracefully: %v\n", err)
+		if err := httpServer.Close(); err != nil {
+			log.Println(err)
+		}
+	}
+
+	log.Println("Shutting down the workers...")
+	stopWorkers()
+	done := make(chan struct{})
+	go func() {
+		waitWorkers()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Println("Failed to stop the workers in time")
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/softpro-junior-assignment/services"
+)
+
+const (
+	ConfigSportsSource   = "config"
+	DatabaseSportsSource = "database"
+)
+
+// sportRegistry хранит доступные спорты, новые спорты можно добавлять на лету:
+// для них создается таблица линий и вызывается onAdd (запуск воркера)
+type sportRegistry struct {
+	sports services.SportsDB
+
+	mu     sync.RWMutex
+	byName map[string]services.Sport
+	onAdd  func(sport services.Sport)
+	// спорты хранилища, пропущенные Load, и причины: о пропуске пишется в лог, только если причина изменилась
+	skipped map[string]string
+}
+
+func newSportRegistry(sports services.SportsDB) *sportRegistry {
+	return &sportRegistry{
+		sports:  sports,
+		byName:  make(map[string]services.Sport),
+		skipped: make(map[string]string),
+	}
+}
+
+// Register добавляет спорт в хранилище и в реестр, либо обновляет его интервал
+func (r *sportRegistry) Register(name string, pollInterval uint) error {
+	sport, err := r.sports.Upsert(name, pollInterval)
+	if err != nil {
+		return err
+	}
+
+	r.add(*sport)
+	return nil
+}
+
+// Load добавляет в реестр спорты из хранилища, которых в нем еще нет, и обновляет интервалы опроса остальных.
+// Спорты с нулевым интервалом или те, для которых не удалось создать таблицу линий (например, с недопустимым именем),
+// пропускаются, чтобы не блокировать добавление остальных
+func (r *sportRegistry) Load() error {
+	sports, err := r.sports.All()
+	if err != nil {
+		return err
+	}
+
+	for _, sport := range sports {
+		if sport.PollInterval == 0 {
+			r.skip(sport.Name, "the poll interval can't be 0")
+			continue
+		}
+
+		if !r.Has(sport.Name) {
+			if err := r.sports.CreateLinesTable(sport.Name); err != nil {
+				r.skip(sport.Name, "failed to create its lines table: "+err.Error())
+				continue
+			}
+		}
+
+		r.mu.Lock()
+		delete(r.skipped, sport.Name)
+		r.mu.Unlock()
+		r.add(sport)
+	}
+
+	return nil
+}
+
+func (r *sportRegistry) skip(name, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.skipped[name] == reason {
+		return
+	}
+	r.skipped[name] = reason
+	log.Printf("Skipped the %v sport from the storage: %v\n", name, reason)
+}
+
+// Watch периодически подгружает новые спорты из хранилища, пока не закрыт abort
+func (r *sportRegistry) Watch(interval time.Duration, abort <-chan struct{}) {
+	for {
+		select {
+		case <-abort:
+			return
+		case <-time.After(interval):
+		}
+
+		if err := r.Load(); err != nil {
+			log.Printf("Failed to reload the sports: %v\n", err)
+		}
+	}
+}
+
+// add добавляет спорт в реестр или обновляет его интервал опроса, воркер спорта подхватывает его со следующего опроса
+func (r *sportRegistry) add(sport services.Sport) {
+	r.mu.Lock()
+	prev, found := r.byName[sport.Name]
+	r.byName[sport.Name] = sport
+	onAdd := r.onAdd
+	r.mu.Unlock()
+
+	if found && prev.PollInterval != sport.PollInterval {
+		log.Printf("Changed the poll interval of the %v sport to %d second(s)\n", sport.Name, sport.PollInterval)
+	}
+	if !found {
+		log.Printf("Registered the %v sport (poll interval is %d second(s))\n", sport.Name, sport.PollInterval)
+		if onAdd != nil {
+			onAdd(sport)
+		}
+	}
+}
+
+// OnAdd задает обработчик спортов, которые будут добавлены в реестр после его вызова
+func (r *sportRegistry) OnAdd(f func(sport services.Sport)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onAdd = f
+}
+
+func (r *sportRegistry) Has(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, found := r.byName[name]
+	return found
+}
+
+func (r *sportRegistry) Get(name string) (services.Sport, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sport, found := r.byName[name]
+	return sport, found
+}
+
+// GetByID ищет спорт по его id в хранилище (он же идентификатор спорта в API v2)
+func (r *sportRegistry) GetByID(id uint) (services.Sport, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, sport := range r.byName {
+		if sport.ID == id {
+			return sport, true
+		}
+	}
+	return services.Sport{}, false
+}
+
+func (r *sportRegistry) All() []services.Sport {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sports := make([]services.Sport, 0, len(r.byName))
+	for _, sport := range r.byName {
+		sports = append(sports, sport)
+	}
+	sort.Slice(sports, func(i, j int) bool { return sports[i].ID < sports[j].ID })
+	return sports
+}
+
+func (r *sportRegistry) Names() []string {
+	var names []string
+	for _, sport := range r.All() {
+		names = append(names, sport.Name)
+	}
+	return names
+}
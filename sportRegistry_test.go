@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/softpro-junior-assignment/services"
+)
+
+// fakeSportsDB - хранилище спортов в памяти, таблицы линий создаются только для допустимых имен
+type fakeSportsDB struct {
+	sports []services.Sport
+}
+
+func (db *fakeSportsDB) All() ([]services.Sport, error) {
+	return db.sports, nil
+}
+
+func (db *fakeSportsDB) Upsert(name string, pollInterval uint) (*services.Sport, error) {
+	sport := services.Sport{ID: uint(len(db.sports) + 1), Name: name, PollInterval: pollInterval}
+	db.sports = append(db.sports, sport)
+	return &sport, nil
+}
+
+func (db *fakeSportsDB) CreateLinesTable(name string) error {
+	if !services.ValidSportName(name) {
+		return services.ErrInvalidSportName
+	}
+	return nil
+}
+
+func TestSportRegistryLoadSkipsInvalidSports(t *testing.T) {
+	db := &fakeSportsDB{sports: []services.Sport{
+		{ID: 1, Name: "baseball", PollInterval: 1},
+		{ID: 2, Name: "Bad Name", PollInterval: 1},
+		{ID: 3, Name: "soccer", PollInterval: 1},
+	}}
+	registry := newSportRegistry(db)
+
+	if err := registry.Load(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !registry.Has("baseball") || !registry.Has("soccer") || registry.Has("Bad Name") {
+		t.Fatalf("Unexpected sports: %v", registry.Names())
+	}
+
+	// спорты, добавленные в хранилище позже, подгружаются несмотря на пропущенный
+	db.sports = append(db.sports, services.Sport{ID: 4, Name: "hockey", PollInterval: 1})
+	if err := registry.Load(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !registry.Has("hockey") {
+		t.Fatalf("The hockey sport was not loaded: %v", registry.Names())
+	}
+}
+
+func TestSportRegistryLoadSkipsZeroIntervalsOnce(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	db := &fakeSportsDB{sports: []services.Sport{{ID: 1, Name: "hockey", PollInterval: 0}}}
+	registry := newSportRegistry(db)
+
+	for i := 0; i < 3; i++ {
+		if err := registry.Load(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if registry.Has("hockey") {
+		t.Fatal("A sport with the 0 poll interval was loaded")
+	}
+	if n := strings.Count(logs.String(), "Skipped the hockey sport"); n != 1 {
+		t.Fatalf("Expected the skipped sport to be logged once, logged %d time(s)", n)
+	}
+
+	// после исправления интервала спорт подгружается
+	db.sports[0].PollInterval = 2
+	if err := registry.Load(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sport, _ := registry.Get("hockey"); sport.PollInterval != 2 {
+		t.Fatalf("Unexpected sport: %+v", sport)
+	}
+}
+
+func TestSportRegistryLoadUpdatesPollIntervals(t *testing.T) {
+	db := &fakeSportsDB{sports: []services.Sport{{ID: 1, Name: "soccer", PollInterval: 1}}}
+	registry := newSportRegistry(db)
+
+	var added int
+	registry.OnAdd(func(sport services.Sport) { added++ })
+
+	if err := registry.Load(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	db.sports[0].PollInterval = 5
+	if err := registry.Load(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sport, _ := registry.Get("soccer"); sport.PollInterval != 5 {
+		t.Fatalf("The poll interval was not updated: %+v", sport)
+	}
+
+	// нулевой интервал не применяется, спорт остается с прежним
+	db.sports[0].PollInterval = 0
+	if err := registry.Load(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sport, _ := registry.Get("soccer"); sport.PollInterval != 5 {
+		t.Fatalf("The 0 poll interval was applied: %+v", sport)
+	}
+
+	// воркер запускается только для новых спортов
+	if added != 1 {
+		t.Fatalf("Expected one sport to be added, got %d", added)
+	}
+}
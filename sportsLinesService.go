@@ -1,163 +1,651 @@
 package main
 
 import (
-	"errors"
-	"github.com/jinzhu/gorm"
+	"context"
+	"encoding/base64"
+	"fmt"
 	_ "github.com/jinzhu/gorm/dialects/postgres"
-	"github.com/lib/pq"
 	"github.com/softpro-junior-assignment/pb"
+	"github.com/softpro-junior-assignment/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"io"
+	"math"
 	"strings"
+	"sync"
 	"time"
 )
 
 type sportsLinesServer struct {
-	db *gorm.DB
+	lines    services.LinesDB
+	cache    *linesCache
+	hub      *hub
+	registry *sportRegistry
+	sv       *supervisor
+	// максимальное кол-во линий в одном ответе GetLineHistory
+	historyMaxPoints uint
+	limits           SubscriptionLimitsConfig
+	streams          *streamLimiter
+	sessions         *sessionStore
+	// закрывается при остановке сервиса, все стримы при этом завершаются с codes.Unavailable
+	shutdown <-chan struct{}
+}
+
+// subscribeRequest - запрос SubscribeOnSportsLines, общий для всех версий API
+type subscribeRequest struct {
+	interval   uint32
+	sportNames []string
+	// имя поля запроса со спортами в этой версии API, для ошибок валидации
+	sportsField string
+	mode        pb.SubscriptionMode
+	threshold   float32
+	resumeToken string
+	// 0 - без heartbeat'ов
+	heartbeatInterval uint32
+}
+
+// subscribeResponse - ответ SubscribeOnSportsLines, общий для всех версий API
+type subscribeResponse struct {
+	seq         uint64
+	resumeToken string
+	lines       []sportLine
+	heartbeat   *heartbeat
+}
+
+type heartbeat struct {
+	serverTime time.Time
+	// линии всех спортов стрима сейчас обновляются
+	synced bool
+}
+
+// sportLine - линия или дельта спорта в ответе
+type sportLine struct {
+	name string
+	line float32
+}
+
+// linesStream - стрим SubscribeOnSportsLines одной из версий API, вся логика стримов работает с ним,
+// а версии API только приводят к нему свои запросы и ответы
+type linesStream interface {
+	Context() context.Context
+	Recv() (*subscribeRequest, error)
+	Send(resp *subscribeResponse) error
+}
+
+type linesStreamV1 struct {
+	pb.SportsLinesService_SubscribeOnSportsLinesServer
+}
+
+func (st linesStreamV1) Recv() (*subscribeRequest, error) {
+	req, err := st.SportsLinesService_SubscribeOnSportsLinesServer.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscribeRequest{
+		interval:    req.Interval,
+		sportNames:  req.SportNames,
+		sportsField: "sport_names",
+		mode:        req.Mode,
+		threshold:   req.Threshold,
+		resumeToken: req.ResumeToken,
+
+		heartbeatInterval: req.HeartbeatInterval,
+	}, nil
+}
+
+func (st linesStreamV1) Send(resp *subscribeResponse) error {
+	res := pb.SubscribeOnSportsLinesResponse{Seq: resp.seq, ResumeToken: resp.resumeToken}
+	for _, line := range resp.lines {
+		res.SportInfos = append(res.SportInfos, &pb.SportInfo{Name: line.name, Line: line.line})
+	}
+	if resp.heartbeat != nil {
+		res.Heartbeat = &pb.Heartbeat{ServerTime: timestamppb.New(resp.heartbeat.serverTime), Synced: resp.heartbeat.synced}
+	}
+	return st.SportsLinesService_SubscribeOnSportsLinesServer.Send(&res)
 }
 
 func (s *sportsLinesServer) SubscribeOnSportsLines(stream pb.SportsLinesService_SubscribeOnSportsLinesServer) error {
-	errs := make(chan error, 2)
-	abortStreamHandler := make(chan struct{}, 1)
+	return s.subscribe(linesStreamV1{stream})
+}
+
+func (s *sportsLinesServer) subscribe(stream linesStream) error {
+	client := clientID(stream.Context())
+	if err := s.streams.Acquire(client); err != nil {
+		return err
+	}
+	defer s.streams.Release(client)
 
-	go streamHandler(stream, abortStreamHandler, errs, s.db)
+	activeStreams.Inc()
+	defer activeStreams.Dec()
+
+	h := &streamHandler{
+		server:     s,
+		stream:     stream,
+		errs:       make(chan error, 1),
+		session:    newStreamSession(principalName(stream.Context())),
+		stopDeltas: func() {},
+	}
+	go h.run()
 
 	select {
-	case e := <-errs:
+	case e := <-h.errs:
+		h.close()
 		if e == io.EOF {
 			return nil
 		}
+		return e
+	case <-s.shutdown:
+		h.close()
+		return status.Error(codes.Unavailable, "The server is shutting down")
+	}
+}
 
-		abortStreamHandler <- struct{}{}
+// streamHandler обрабатывает запросы одного стрима: на каждый запрос присылает текущие линии (если изменился
+// набор спортов) и перезапускает sendDeltas с новыми параметрами
+type streamHandler struct {
+	server *sportsLinesServer
+	stream linesStream
+	// первая ошибка стрима, остальные отбрасываются
+	errs chan error
 
-		switch e.(type) {
-		case *pq.Error:
-			return errors.New("There is a problem with getting lines from the storage")
+	mu      sync.Mutex
+	closed  bool
+	session *streamSession
+	// останавливает текущий sendDeltas и дожидается его завершения
+	stopDeltas func()
+	// когда был отправлен последний ответ, heartbeat'ы присылаются только в паузах между ответами
+	lastSendAt time.Time
+}
+
+func (h *streamHandler) fail(err error) {
+	select {
+	case h.errs <- err:
+	default:
+	}
+}
+
+func (h *streamHandler) run() {
+	for {
+		req, err := h.stream.Recv()
+		if err != nil {
+			h.fail(err)
+			return
 		}
 
-		return e
+		if err := h.handle(req); err != nil {
+			h.fail(err)
+			return
+		}
 	}
 }
 
-func streamHandler(stream pb.SportsLinesService_SubscribeOnSportsLinesServer, abortStreamHandler <-chan struct{}, errs chan<- error, db *gorm.DB) {
-	prevParamsSet := make(Set)
-	abortSendDeltas := make(chan struct{})
+func (h *streamHandler) handle(req *subscribeRequest) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 
-	// dummy func, только для того, чтоб при старте когда запрос придет было что "абортить", см далее
-	go func() {
-		<-abortSendDeltas
-	}()
+	if h.closed {
+		return nil
+	}
 
-	for {
-		req, err := stream.Recv()
+	// при ошибке валидации запроса sendDeltas уже остановлен, и close не должен останавливать его повторно
+	h.stopDeltas()
+	h.stopDeltas = func() {}
 
-		select {
-		case <-abortStreamHandler:
-			return
-		default:
+	s := h.server
+	if err := s.validateInterval("interval", req.interval); err != nil {
+		return err
+	}
+
+	if req.heartbeatInterval != 0 {
+		if err := s.validateInterval("heartbeat_interval", req.heartbeatInterval); err != nil {
+			return err
 		}
+	}
 
-		// стопаем SendDeltas либо dummyfunc при старте streamHandler, строго небуферизированный
-		abortSendDeltas <- struct{}{}
+	if err := s.validateSportNames(h.stream.Context(), req.sportsField, req.sportNames); err != nil {
+		return err
+	}
 
-		if err != nil {
-			errs <- err
-			return
+	if err := s.checkFresh(req.sportNames); err != nil {
+		return err
+	}
+
+	filter, err := newDeltasFilter(req.mode, req.threshold)
+	if err != nil {
+		return err
+	}
+
+	if req.resumeToken != "" {
+		if h.session.seq != 0 {
+			return invalidArgument("resume_token", "A resume token is accepted only in the first request of a stream")
 		}
 
-		if req.Interval == 0 {
-			errs <- errors.New("Interval was not provided")
-			return
+		session, found := s.sessions.Take(req.resumeToken, h.session.principal)
+		if !found {
+			return invalidArgument("resume_token", "The resume token is unknown or expired, subscribe without it to get the current lines")
 		}
+		h.session = session
+	}
 
-		if req.SportNames == nil {
-			errs <- errors.New("Sport names were not provided")
-			return
+	var notBefore time.Time
+	newParamsSet := NewSetFromSlice(req.sportNames)
+	if len(newParamsSet) != len(h.session.params) || !newParamsSet.IsSubsetOf(h.session.params) {
+		if err := h.sendLines(newParamsSet); err != nil {
+			return err
 		}
 
-		if len(req.SportNames) > 3 {
-			errs <- errors.New("More than 3 sport names provided")
-			return
+		// чтобы не присылать почти сразу нулевые дельты, клиентам, которым они вообще не нужны, подходят ON_CHANGE и ON_THRESHOLD
+		if filter.mode == pb.SubscriptionMode_EVERY_TICK {
+			notBefore = time.Now().Add(time.Duration(req.interval) * time.Second)
 		}
+	}
+	// иначе базовые линии остаются прежними
 
-		for _, name := range req.SportNames {
-			_, found := AvailableSportNames[name]
-			if !found {
-				errs <- errors.New("A sport name must be one of the following: " + strings.Join(AvailableSportNames.GetKeys(), ", "))
-				return
-			}
+	// с первого ответа стрим можно возобновить, в том числе пока этот стрим еще открыт
+	if h.session.seq != 0 {
+		s.sessions.Open(h.session, h.detach)
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := h.sendDeltas(req, filter, notBefore, stop); err != nil {
+			h.fail(err)
 		}
+	}()
+	h.stopDeltas = func() {
+		close(stop)
+		<-done
+	}
 
-		newParamsSet := NewSetFromSlice(req.SportNames)
-		if len(newParamsSet) == len(prevParamsSet) && newParamsSet.IsSubsetOf(prevParamsSet) {
-			go sendDeltas(req.Interval, db, abortSendDeltas, errs, newParamsSet, stream)
-		} else {
-			err = sendLines(db, newParamsSet, stream)
-			if err != nil {
-				errs <- err
-				return
-			}
+	return nil
+}
+
+// close останавливает обработку запросов стрима и сохраняет его сессию, чтобы клиент мог возобновить стрим
+func (h *streamHandler) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	h.closed = true
+	h.stopDeltas()
+	h.stopDeltas = func() {}
+
+	if h.session.seq != 0 {
+		h.server.sessions.Put(h.session)
+	}
+}
+
+// detach завершает стрим, сессию которого возобновляет другой стрим. Завершения стрима он не дожидается:
+// отправка в оборвавшийся стрим может висеть, пока его не закроет keepalive
+func (h *streamHandler) detach() {
+	h.fail(status.Error(codes.Aborted, "The stream was resumed by another stream"))
+}
+
+// send присылает линии (kind "lines") или дельты (kind "deltas")
+func (h *streamHandler) send(kind string, lines []sportLine) error {
+	h.session.mu.Lock()
+	h.session.seq++
+	seq := h.session.seq
+	h.session.mu.Unlock()
+
+	h.lastSendAt = time.Now()
+	if err := h.stream.Send(&subscribeResponse{seq: seq, resumeToken: h.session.token, lines: lines}); err != nil {
+		return err
+	}
+	streamMessagesSent.WithLabelValues(kind).Inc()
+	return nil
+}
+
+func (h *streamHandler) sendHeartbeat() error {
+	h.session.mu.Lock()
+	seq, sportNames := h.session.seq, h.session.params.GetKeys()
+	h.session.mu.Unlock()
+
+	h.lastSendAt = time.Now()
+	defer streamMessagesSent.WithLabelValues("heartbeat").Inc()
+	return h.stream.Send(&subscribeResponse{
+		seq:         seq,
+		resumeToken: h.session.token,
+		heartbeat: &heartbeat{
+			serverTime: h.lastSendAt,
+			synced:     isSynced && len(h.server.staleReasons(sportNames)) == 0,
+		},
+	})
+}
+
+// validateSportNames проверяет имена спортов из поля запроса field
+func (s *sportsLinesServer) validateSportNames(ctx context.Context, field string, names []string) error {
+	if names == nil {
+		return invalidArgument(field, "Sport names were not provided")
+	}
+
+	if max := s.limits.MaxSports; max != 0 && uint(len(NewSetFromSlice(names))) > max {
+		return invalidArgument(field, fmt.Sprintf("More than %d sport names provided", max))
+	}
+
+	for _, name := range names {
+		if err := s.validateSportName(ctx, field, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *sportsLinesServer) validateInterval(field string, interval uint32) error {
+	if interval == 0 {
+		return invalidArgument(field, "Interval was not provided")
+	}
+
+	if interval < s.limits.MinInterval || interval > s.limits.MaxInterval {
+		return invalidArgument(field, fmt.Sprintf("Interval must be within the range [%d, %d] seconds", s.limits.MinInterval, s.limits.MaxInterval))
+	}
+
+	return nil
+}
 
-			// todo можно подождать, прежде чем присылать почти сразу нулевые дельты, но возможно все-таки этого не стоит делать?
-			time.Sleep(time.Duration(req.Interval) * time.Second)
+// validateSportName проверяет, что спорт существует и доступен клиенту. Недоступные спорты для клиента
+// не отличаются от несуществующих, и в ошибке перечисляются только доступные, чтобы не раскрывать ему остальные
+func (s *sportsLinesServer) validateSportName(ctx context.Context, field, name string) error {
+	if !s.registry.Has(name) || !principalFromContext(ctx).Entitled(name) {
+		return invalidArgument(field, "A sport name must be one of the following: "+strings.Join(s.entitledSportNames(ctx), ", "))
+	}
+	return nil
+}
+
+// entitledSportNames возвращает имена спортов, доступных клиенту запроса
+func (s *sportsLinesServer) entitledSportNames(ctx context.Context) []string {
+	p := principalFromContext(ctx)
 
-			go sendDeltas(req.Interval, db, abortSendDeltas, errs, newParamsSet, stream)
+	var names []string
+	for _, name := range s.registry.Names() {
+		if p.Entitled(name) {
+			names = append(names, name)
 		}
+	}
+	return names
+}
 
-		prevParamsSet = newParamsSet
+// checkFresh возвращает ошибку, если линии каких-то из спортов names сейчас не обновляются
+func (s *sportsLinesServer) checkFresh(names []string) error {
+	if stale := s.staleReasons(names); len(stale) != 0 {
+		return staleLines(stale)
 	}
+	return nil
+}
 
+// staleReasons возвращает спорты из names, линии которых сейчас не обновляются, и причины этого
+func (s *sportsLinesServer) staleReasons(names []string) map[string]string {
+	stale := make(map[string]string)
+	for _, name := range names {
+		if status, found := s.sv.Status(name); found && status.State != WorkerRunning {
+			stale[name] = "The worker is " + status.State.String()
+		}
+	}
+	return stale
 }
 
-// в params уже должны быть линии, от которых будут присылаться дельты, эта горутина всегда присылает только дельты
-func sendDeltas(interval uint32, db *gorm.DB, abort <-chan struct{}, errs chan<- error, params Set, stream pb.SportsLinesService_SubscribeOnSportsLinesServer) {
+// sendDeltas присылает только дельты от базовых линий сессии, пока не закрыт stop. Дельты раньше notBefore не присылаются.
+// lastSent сессии обновляется по мере отправки дельт и переходит следующему sendDeltas этого стрима
+func (h *streamHandler) sendDeltas(req *subscribeRequest, filter deltasFilter, notBefore time.Time, stop <-chan struct{}) error {
+	h.session.mu.Lock()
+	params, lastSent := h.session.params, h.session.lastSent
+	h.session.mu.Unlock()
+
+	sub := h.server.hub.Subscribe(req.interval, params.GetKeys())
+	defer sub.Close()
+
+	// nil канал, если heartbeat'ы не нужны
+	var heartbeats <-chan time.Time
+	if req.heartbeatInterval != 0 {
+		ticker := time.NewTicker(time.Duration(req.heartbeatInterval) * time.Second)
+		defer ticker.Stop()
+		heartbeats = ticker.C
+	}
+	heartbeatInterval := time.Duration(req.heartbeatInterval) * time.Second
+
 	for {
 		select {
-		case <-abort:
-			return
-		default:
-			// todo возможно ли сделать одним запросом получение всех линий? и так и этак думал, но что-то не придумал
-			var resp pb.SubscribeOnSportsLinesResponse
+		case <-stop:
+			return nil
+		case <-heartbeats:
+			if time.Since(h.lastSendAt) < heartbeatInterval {
+				continue
+			}
+
+			if err := h.sendHeartbeat(); err != nil {
+				return err
+			}
+		case update := <-sub.C:
+			if update.err != nil {
+				return latestLineError(update.err)
+			}
+
+			if time.Now().Before(notBefore) {
+				continue
+			}
+
+			var deltas []sportLine
+			h.session.mu.Lock()
 			for sportName, line := range params {
-				sportInfo := pb.SportInfo{Name: sportName}
-				query := `SELECT line FROM ` + sportName + `s ORDER BY id DESC LIMIT 1`
-				err := db.Raw(query).Scan(&sportInfo).Error
-				if err != nil {
-					errs <- err
-					return
+				latest := update.lines[sportName].Line
+				if !filter.changed(lastSent[sportName], latest) {
+					continue
 				}
-				sportInfo.Line = line - sportInfo.Line
-				resp.SportInfos = append(resp.SportInfos, &sportInfo)
+				lastSent[sportName] = latest
+				deltas = append(deltas, sportLine{name: sportName, line: line - latest})
 			}
+			h.session.mu.Unlock()
 
-			err := stream.Send(&resp)
-			if err != nil {
-				errs <- err
-				return
+			if deltas == nil {
+				continue
 			}
 
-			time.Sleep(time.Duration(interval) * time.Second)
+			if err := h.send("deltas", deltas); err != nil {
+				return err
+			}
 		}
 	}
 }
 
-func sendLines(db *gorm.DB, params Set, stream pb.SportsLinesService_SubscribeOnSportsLinesServer) error {
-	// todo возможно ли сделать одним запросом получение всех линий? и так и этак думал, но что-то не придумал
-	var resp pb.SubscribeOnSportsLinesResponse
+// deltasFilter решает, присылать ли дельту спорта, согласно режиму подписки
+type deltasFilter struct {
+	mode      pb.SubscriptionMode
+	threshold float32
+}
+
+func newDeltasFilter(mode pb.SubscriptionMode, threshold float32) (deltasFilter, error) {
+	switch mode {
+	case pb.SubscriptionMode_EVERY_TICK, pb.SubscriptionMode_ON_CHANGE:
+	case pb.SubscriptionMode_ON_THRESHOLD:
+		if !(threshold > 0) || math.IsInf(float64(threshold), 0) {
+			return deltasFilter{}, invalidArgument("threshold", "Threshold must be a positive number in the ON_THRESHOLD mode")
+		}
+	default:
+		return deltasFilter{}, invalidArgument("mode", "Unknown subscription mode")
+	}
+
+	return deltasFilter{mode: mode, threshold: threshold}, nil
+}
+
+// changed сообщает, нужно ли присылать дельту, если последней была прислана дельта от линии prev, а текущая линия - cur
+func (f deltasFilter) changed(prev, cur float32) bool {
+	switch f.mode {
+	case pb.SubscriptionMode_ON_CHANGE:
+		return cur != prev
+	case pb.SubscriptionMode_ON_THRESHOLD:
+		return float32(math.Abs(float64(cur-prev))) > f.threshold
+	}
+	return true
+}
+
+// sendLines присылает текущие линии спортов params, они становятся базовыми линиями сессии
+func (h *streamHandler) sendLines(params Set) error {
+	var lines []sportLine
 	for sportName := range params {
-		sportInfo := pb.SportInfo{Name: sportName}
-		query := `SELECT line FROM ` + sportName + `s ORDER BY id DESC LIMIT 1`
-		err := db.Raw(query).Scan(&sportInfo).Error
+		latest, err := h.server.cache.Latest(sportName)
 		if err != nil {
-			return err
+			return latestLineError(err)
 		}
-		params[sportName] = sportInfo.Line
-		resp.SportInfos = append(resp.SportInfos, &sportInfo)
+		params[sportName] = latest.Line
+		lines = append(lines, sportLine{name: sportName, line: latest.Line})
 	}
 
-	err := stream.Send(&resp)
+	lastSent := make(Set, len(params))
+	for name, line := range params {
+		lastSent[name] = line
+	}
+	h.session.mu.Lock()
+	h.session.params, h.session.lastSent = params, lastSent
+	h.session.mu.Unlock()
+
+	return h.send("lines", lines)
+}
+
+func (s *sportsLinesServer) GetLatestLines(ctx context.Context, req *pb.GetLatestLinesRequest) (*pb.GetLatestLinesResponse, error) {
+	lines, err := s.latestLines(ctx, "sport_names", req.SportNames)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	var resp pb.GetLatestLinesResponse
+	for _, line := range lines {
+		resp.SportInfos = append(resp.SportInfos, &pb.SportInfo{Name: line.name, Line: line.line})
+	}
+
+	return &resp, nil
+}
+
+// latestLines возвращает текущие линии спортов names (из поля запроса field) в порядке запроса, без повторов
+func (s *sportsLinesServer) latestLines(ctx context.Context, field string, names []string) ([]sportLine, error) {
+	if err := s.validateSportNames(ctx, field, names); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkFresh(names); err != nil {
+		return nil, err
+	}
+
+	var lines []sportLine
+	seen := make(Set, len(names))
+	for _, name := range names {
+		if _, found := seen[name]; found {
+			continue
+		}
+		seen[name] = 0
+
+		latest, err := s.cache.Latest(name)
+		if err != nil {
+			return nil, latestLineError(err)
+		}
+		lines = append(lines, sportLine{name: name, line: latest.Line})
+	}
+
+	return lines, nil
+}
+
+func (s *sportsLinesServer) GetLineHistory(ctx context.Context, req *pb.GetLineHistoryRequest) (*pb.GetLineHistoryResponse, error) {
+	if err := s.validateSportName(ctx, "sport_name", req.SportName); err != nil {
+		return nil, err
+	}
+
+	lines, nextPageToken, err := s.lineHistory(req.SportName, req.From, req.To, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := pb.GetLineHistoryResponse{NextPageToken: nextPageToken}
+	for _, line := range lines {
+		historyLine := pb.HistoryLine{
+			Line:      line.Line,
+			FetchedAt: timestamppb.New(line.FetchedAt),
+			Source:    line.Source,
+		}
+		if line.ProviderTime != nil {
+			historyLine.ProviderTime = timestamppb.New(*line.ProviderTime)
+		}
+		resp.Lines = append(resp.Lines, &historyLine)
+	}
+
+	return &resp, nil
+}
+
+// lineHistory возвращает страницу истории линий спорта sportName и токен следующей страницы
+func (s *sportsLinesServer) lineHistory(sportName string, fromTS, toTS *timestamppb.Timestamp, reqPageSize uint32, pageToken string) ([]services.Line, string, error) {
+	var from time.Time
+	if fromTS != nil {
+		if err := fromTS.CheckValid(); err != nil {
+			return nil, "", invalidArgument("from", "Invalid timestamp: "+err.Error())
+		}
+		from = fromTS.AsTime()
+	}
+
+	to := time.Now()
+	if toTS != nil {
+		if err := toTS.CheckValid(); err != nil {
+			return nil, "", invalidArgument("to", "Invalid timestamp: "+err.Error())
+		}
+		to = toTS.AsTime()
+	}
+
+	if to.Before(from) {
+		return nil, "", invalidArgument("from", "'from' must not be after 'to'")
+	}
+
+	pageSize := s.historyMaxPoints
+	if reqPageSize != 0 && uint(reqPageSize) < pageSize {
+		pageSize = uint(reqPageSize)
+	}
+
+	var after *services.HistoryCursor
+	if pageToken != "" {
+		cursor, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, "", invalidArgument("page_token", "Invalid page token")
+		}
+		after = &cursor
+	}
+
+	// на одну больше, чтобы понять, есть ли следующая страница
+	lines, err := s.lines.History(sportName, from, to, after, pageSize+1)
+	if err != nil {
+		return nil, "", storageUnavailable(err)
+	}
+
+	var nextPageToken string
+	if uint(len(lines)) > pageSize {
+		lines = lines[:pageSize]
+		last := lines[len(lines)-1]
+		nextPageToken = encodePageToken(services.HistoryCursor{FetchedAt: last.FetchedAt, ID: last.ID})
+	}
+
+	return lines, nextPageToken, nil
+}
+
+// page token - это позиция последней линии страницы в виде "<fetched_at в наносекундах>.<id>" в base64
+func encodePageToken(cursor services.HistoryCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d.%d", cursor.FetchedAt.UnixNano(), cursor.ID)))
+}
+
+func decodePageToken(token string) (services.HistoryCursor, error) {
+	var cursor services.HistoryCursor
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, err
+	}
+
+	var nanos int64
+	if _, err := fmt.Sscanf(string(b), "%d.%d", &nanos, &cursor.ID); err != nil {
+		return cursor, err
+	}
+	cursor.FetchedAt = time.Unix(0, nanos)
+
+	return cursor, nil
 }
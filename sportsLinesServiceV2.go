@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/softpro-junior-assignment/pb"
+	pbv2 "github.com/softpro-junior-assignment/pb/v2"
+	"github.com/softpro-junior-assignment/services"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// sportsLinesServerV2 - API v2 поверх той же логики, что и у первой версии: спорты в запросах и ответах
+// задаются их id в хранилище, а дальше, как и в v1, используются их имена
+type sportsLinesServerV2 struct {
+	*sportsLinesServer
+}
+
+// sportName возвращает имя спорта sport из поля запроса field; недоступные клиенту спорты, как и в v1,
+// не отличаются от несуществующих
+func (s sportsLinesServerV2) sportName(ctx context.Context, field string, sport pbv2.Sport) (string, error) {
+	if sport < 0 {
+		return "", invalidArgument(field, "Unknown sport: "+strconv.Itoa(int(sport)))
+	}
+
+	found, ok := s.registry.GetByID(uint(sport))
+	if !ok || !principalFromContext(ctx).Entitled(found.Name) {
+		return "", invalidArgument(field, "Unknown sport: "+strconv.Itoa(int(sport))+", the available sports are listed by ListSports")
+	}
+	return found.Name, nil
+}
+
+func (s sportsLinesServerV2) sportNames(ctx context.Context, field string, sports []pbv2.Sport) ([]string, error) {
+	var names []string
+	for _, sport := range sports {
+		name, err := s.sportName(ctx, field, sport)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (s sportsLinesServerV2) sportID(name string) pbv2.Sport {
+	sport, _ := s.registry.Get(name)
+	return pbv2.Sport(sport.ID)
+}
+
+// checkSportIDs проверяет, что спорты из enum Sport имеют в хранилище те же id, что и в enum: иначе, например,
+// если строки таблицы sports добавлялись в другом порядке, v2 молча отдавал бы линии не тех спортов
+func checkSportIDs(registry *sportRegistry) error {
+	for id := int32(1); id < int32(len(pbv2.Sport_name)); id++ {
+		enumName, found := pbv2.Sport_name[id]
+		if !found {
+			continue
+		}
+		name := strings.ToLower(enumName)
+
+		if sport, found := registry.Get(name); found && sport.ID != uint(id) {
+			return fmt.Errorf("The %v sport has id %d in the storage, but %d in the API v2", name, sport.ID, id)
+		}
+		if sport, found := registry.GetByID(uint(id)); found && sport.Name != name {
+			return fmt.Errorf("The %v sport has id %d in the storage, which is the id of %v in the API v2", sport.Name, id, name)
+		}
+	}
+	return nil
+}
+
+type linesStreamV2 struct {
+	pbv2.SportsLinesService_SubscribeOnSportsLinesServer
+	s sportsLinesServerV2
+}
+
+func (st linesStreamV2) Recv() (*subscribeRequest, error) {
+	req, err := st.SportsLinesService_SubscribeOnSportsLinesServer.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := st.s.sportNames(st.Context(), "sports", req.Sports)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscribeRequest{
+		interval:    req.Interval,
+		sportNames:  names,
+		sportsField: "sports",
+		// значения режимов в v1 и v2 совпадают
+		mode:        pb.SubscriptionMode(req.Mode),
+		threshold:   req.Threshold,
+		resumeToken: req.ResumeToken,
+
+		heartbeatInterval: req.HeartbeatInterval,
+	}, nil
+}
+
+func (st linesStreamV2) Send(resp *subscribeResponse) error {
+	res := pbv2.SubscribeOnSportsLinesResponse{Seq: resp.seq, ResumeToken: resp.resumeToken}
+	for _, line := range resp.lines {
+		res.Lines = append(res.Lines, &pbv2.SportLine{Sport: st.s.sportID(line.name), Line: line.line})
+	}
+	if resp.heartbeat != nil {
+		res.Heartbeat = &pbv2.Heartbeat{ServerTime: timestamppb.New(resp.heartbeat.serverTime), Synced: resp.heartbeat.synced}
+	}
+	return st.SportsLinesService_SubscribeOnSportsLinesServer.Send(&res)
+}
+
+func (s sportsLinesServerV2) SubscribeOnSportsLines(stream pbv2.SportsLinesService_SubscribeOnSportsLinesServer) error {
+	return s.subscribe(linesStreamV2{stream, s})
+}
+
+func (s sportsLinesServerV2) GetLatestLines(ctx context.Context, req *pbv2.GetLatestLinesRequest) (*pbv2.GetLatestLinesResponse, error) {
+	names, err := s.sportNames(ctx, "sports", req.Sports)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := s.latestLines(ctx, "sports", names)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp pbv2.GetLatestLinesResponse
+	for _, line := range lines {
+		resp.Lines = append(resp.Lines, &pbv2.SportLine{Sport: s.sportID(line.name), Line: line.line})
+	}
+
+	return &resp, nil
+}
+
+func (s sportsLinesServerV2) GetLineHistory(ctx context.Context, req *pbv2.GetLineHistoryRequest) (*pbv2.GetLineHistoryResponse, error) {
+	name, err := s.sportName(ctx, "sport", req.Sport)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, nextPageToken, err := s.lineHistory(name, req.From, req.To, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := pbv2.GetLineHistoryResponse{NextPageToken: nextPageToken}
+	for _, line := range lines {
+		resp.Lines = append(resp.Lines, historyLineV2(line))
+	}
+
+	return &resp, nil
+}
+
+func historyLineV2(line services.Line) *pbv2.HistoryLine {
+	historyLine := pbv2.HistoryLine{
+		Line:      line.Line,
+		FetchedAt: timestamppb.New(line.FetchedAt),
+		Source:    line.Source,
+	}
+	if line.ProviderTime != nil {
+		historyLine.ProviderTime = timestamppb.New(*line.ProviderTime)
+	}
+	return &historyLine
+}
+
+// ListSports возвращает все доступные клиенту спорты, в т.ч. добавленные после выпуска этой версии API
+func (s sportsLinesServerV2) ListSports(ctx context.Context, req *pbv2.ListSportsRequest) (*pbv2.ListSportsResponse, error) {
+	p := principalFromContext(ctx)
+
+	var resp pbv2.ListSportsResponse
+	for _, sport := range s.registry.All() {
+		if !p.Entitled(sport.Name) {
+			continue
+		}
+
+		resp.Sports = append(resp.Sports, &pbv2.SportDescriptor{
+			Sport:        pbv2.Sport(sport.ID),
+			Name:         sport.Name,
+			PollInterval: uint32(sport.PollInterval),
+		})
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/softpro-junior-assignment/services"
+)
+
+func TestCheckSportIDs(t *testing.T) {
+	tests := []struct {
+		name   string
+		sports []services.Sport
+		valid  bool
+	}{
+		{"same ids", []services.Sport{{ID: 1, Name: "baseball"}, {ID: 2, Name: "football"}, {ID: 3, Name: "soccer"}}, true},
+		{"added later", []services.Sport{{ID: 1, Name: "baseball"}, {ID: 2, Name: "football"}, {ID: 3, Name: "soccer"}, {ID: 4, Name: "hockey"}}, true},
+		{"some of the enum", []services.Sport{{ID: 3, Name: "soccer"}}, true},
+		{"swapped", []services.Sport{{ID: 1, Name: "football"}, {ID: 2, Name: "baseball"}, {ID: 3, Name: "soccer"}}, false},
+		{"enum sport with another id", []services.Sport{{ID: 5, Name: "soccer"}}, false},
+		{"enum id taken by another sport", []services.Sport{{ID: 2, Name: "hockey"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := newSportRegistry(nil)
+			for _, sport := range tt.sports {
+				registry.add(sport)
+			}
+
+			if err := checkSportIDs(registry); (err == nil) != tt.valid {
+				t.Fatalf("Expected valid: %v, got %v", tt.valid, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/softpro-junior-assignment/pb"
+	"github.com/softpro-junior-assignment/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeLinesStream - стрим SubscribeOnSportsLines без сети: запросы берутся из reqs, ответы складываются в sent
+type fakeLinesStream struct {
+	ctx  context.Context
+	reqs chan *subscribeRequest
+	sent chan *subscribeResponse
+	// если true, Send висит, пока не закрыт release, как при заполненном окне flow control оборвавшегося клиента
+	stuck   atomic.Bool
+	release chan struct{}
+}
+
+func newFakeLinesStream(ctx context.Context) *fakeLinesStream {
+	return &fakeLinesStream{
+		ctx:     ctx,
+		reqs:    make(chan *subscribeRequest),
+		sent:    make(chan *subscribeResponse, 100),
+		release: make(chan struct{}),
+	}
+}
+
+func (st *fakeLinesStream) Context() context.Context {
+	return st.ctx
+}
+
+func (st *fakeLinesStream) Recv() (*subscribeRequest, error) {
+	req, ok := <-st.reqs
+	if !ok {
+		return nil, io.EOF
+	}
+	return req, nil
+}
+
+func (st *fakeLinesStream) Send(resp *subscribeResponse) error {
+	if st.stuck.Load() {
+		<-st.release
+		return io.EOF
+	}
+
+	select {
+	case st.sent <- resp:
+	default:
+	}
+	return nil
+}
+
+// next ждет следующий ответ стрима
+func (st *fakeLinesStream) next(t *testing.T) *subscribeResponse {
+	t.Helper()
+	select {
+	case resp := <-st.sent:
+		return resp
+	case <-time.After(5 * time.Second):
+		t.Fatal("No response was sent")
+		return nil
+	}
+}
+
+// newTestServer возвращает сервер без хранилища, линии спортов sportNames уже лежат в кэше
+func newTestServer(t *testing.T, sportNames ...string) *sportsLinesServer {
+	t.Helper()
+
+	abort := make(chan struct{})
+	t.Cleanup(func() { close(abort) })
+
+	registry := newSportRegistry(nil)
+	cache := newLinesCache(nil)
+	for i, name := range sportNames {
+		registry.add(services.Sport{ID: uint(i + 1), Name: name, PollInterval: 1})
+		cache.Set(name, services.Line{Line: 1.5, FetchedAt: time.Now()})
+	}
+
+	limits := DefaultSubscriptionLimitsConfig()
+	return &sportsLinesServer{
+		cache:            cache,
+		hub:              newHub(cache),
+		registry:         registry,
+		sv:               newSupervisor(time.Second, time.Second, 0, abort),
+		historyMaxPoints: 100,
+		limits:           limits,
+		streams:          newStreamLimiter(limits),
+		sessions:         newSessionStore(time.Minute),
+		shutdown:         abort,
+	}
+}
+
+func subscribeAsync(s *sportsLinesServer, stream linesStream) <-chan error {
+	done := make(chan error, 1)
+	go func() { done <- s.subscribe(stream) }()
+	return done
+}
+
+func waitSubscribe(t *testing.T, done <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("The stream did not finish")
+		return nil
+	}
+}
+
+func TestSubscribeInvalidRequestAfterValid(t *testing.T) {
+	valid := subscribeRequest{interval: 1, sportNames: []string{"soccer"}, sportsField: "sport_names"}
+
+	tests := []struct {
+		name   string
+		modify func(req *subscribeRequest)
+	}{
+		{"zero interval", func(req *subscribeRequest) { req.interval = 0 }},
+		{"interval out of range", func(req *subscribeRequest) { req.interval = 1 << 30 }},
+		{"invalid heartbeat interval", func(req *subscribeRequest) { req.heartbeatInterval = 1 << 30 }},
+		{"unknown sport", func(req *subscribeRequest) { req.sportNames = []string{"curling"} }},
+		{"no sports", func(req *subscribeRequest) { req.sportNames = nil }},
+		{"unknown mode", func(req *subscribeRequest) { req.mode = pb.SubscriptionMode(42) }},
+		{"invalid threshold", func(req *subscribeRequest) { req.mode = pb.SubscriptionMode_ON_THRESHOLD }},
+		{"resume token in a later request", func(req *subscribeRequest) { req.resumeToken = "token" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, "soccer")
+			stream := newFakeLinesStream(context.Background())
+			done := subscribeAsync(s, stream)
+
+			first := valid
+			stream.reqs <- &first
+			if resp := stream.next(t); len(resp.lines) != 1 || resp.lines[0].line != 1.5 {
+				t.Fatalf("Unexpected lines: %+v", resp.lines)
+			}
+
+			second := valid
+			tt.modify(&second)
+			stream.reqs <- &second
+
+			if err := waitSubscribe(t, done); status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSubscribeResumeOpenStream(t *testing.T) {
+	s := newTestServer(t, "soccer")
+
+	// первый стрим "оборвался" со стороны клиента, но сервер еще не знает об этом
+	oldStream := newFakeLinesStream(context.Background())
+	oldDone := subscribeAsync(s, oldStream)
+	oldStream.reqs <- &subscribeRequest{interval: 1, sportNames: []string{"soccer"}, sportsField: "sport_names"}
+	first := oldStream.next(t)
+
+	newStream := newFakeLinesStream(context.Background())
+	newDone := subscribeAsync(s, newStream)
+	newStream.reqs <- &subscribeRequest{interval: 1, sportNames: []string{"soccer"}, sportsField: "sport_names", resumeToken: first.resumeToken}
+
+	if err := waitSubscribe(t, oldDone); status.Code(err) != codes.Aborted {
+		t.Fatalf("Expected the old stream to be aborted, got %v", err)
+	}
+
+	// базовые линии прежние, поэтому сразу идут дельты, продолжая нумерацию старого стрима
+	resp := newStream.next(t)
+	if resp.resumeToken != first.resumeToken || resp.seq <= first.seq || resp.lines[0].line != 0 {
+		t.Fatalf("Unexpected response of the resumed stream: %+v", resp)
+	}
+
+	close(newStream.reqs)
+	if err := waitSubscribe(t, newDone); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestSubscribeResumeStuckStream(t *testing.T) {
+	s := newTestServer(t, "soccer")
+
+	oldStream := newFakeLinesStream(context.Background())
+	oldDone := subscribeAsync(s, oldStream)
+	oldStream.reqs <- &subscribeRequest{interval: 1, sportNames: []string{"soccer"}, sportsField: "sport_names"}
+	first := oldStream.next(t)
+
+	// следующая дельта старому стриму зависает в Send
+	oldStream.stuck.Store(true)
+	time.Sleep(1500 * time.Millisecond)
+
+	newStream := newFakeLinesStream(context.Background())
+	newDone := subscribeAsync(s, newStream)
+	newStream.reqs <- &subscribeRequest{interval: 1, sportNames: []string{"soccer"}, sportsField: "sport_names", resumeToken: first.resumeToken}
+
+	// возобновление не ждет, пока отвиснет старый стрим
+	if resp := newStream.next(t); resp.resumeToken != first.resumeToken || resp.seq <= first.seq {
+		t.Fatalf("Unexpected response of the resumed stream: %+v", resp)
+	}
+
+	close(oldStream.release)
+	if err := waitSubscribe(t, oldDone); status.Code(err) != codes.Aborted {
+		t.Fatalf("Expected the old stream to be aborted, got %v", err)
+	}
+
+	close(newStream.reqs)
+	if err := waitSubscribe(t, newDone); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+)
+
+// streamSession - состояние стрима SubscribeOnSportsLines, которое переживает его обрыв:
+// по resume token новый стрим продолжает с тех же базовых линий и номера ответа
+type streamSession struct {
+	// seq, params и lastSent меняются при отправке ответов, а копируются при возобновлении сессии другим стримом
+	mu sync.Mutex
+
+	token string
+	// имя клиента, открывшего стрим, возобновить стрим может только он
+	principal string
+	// номер последнего отправленного ответа
+	seq uint64
+	// базовые линии, от которых считаются дельты
+	params Set
+	// линии, дельты от которых были присланы последними, нужны режимам ON_CHANGE и ON_THRESHOLD
+	lastSent Set
+}
+
+func newStreamSession(principal string) *streamSession {
+	b := make([]byte, 16)
+	// crypto/rand на поддерживаемых ОС не возвращает ошибок
+	_, err := rand.Read(b)
+	must(err)
+
+	return &streamSession{
+		token:     base64.RawURLEncoding.EncodeToString(b),
+		principal: principal,
+		params:    make(Set),
+		lastSent:  make(Set),
+	}
+}
+
+// snapshot возвращает копию сессии, которую может продолжить другой стрим, пока этот еще не завершился
+func (s *streamSession) snapshot() *streamSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := &streamSession{
+		token:     s.token,
+		principal: s.principal,
+		seq:       s.seq,
+		params:    make(Set, len(s.params)),
+		lastSent:  make(Set, len(s.lastSent)),
+	}
+	for name, line := range s.params {
+		c.params[name] = line
+	}
+	for name, line := range s.lastSent {
+		c.lastSent[name] = line
+	}
+	return c
+}
+
+// sessionStore хранит сессии открытых стримов, пока они не завершатся, и сессии оборвавшихся стримов в течение ttl.
+// Клиент может возобновить сессию стрима, который оборвался у него, но еще не завершен на сервере
+// (до этого сервер может узнать об обрыве только через keepalive), тогда старый стрим завершается
+type sessionStore struct {
+	ttl time.Duration
+
+	mu       sync.Mutex
+	sessions map[string]storedSession
+}
+
+type storedSession struct {
+	session *streamSession
+	expires time.Time
+	// у сессий открытых стримов: завершает стрим
+	detach func()
+}
+
+func newSessionStore(ttl time.Duration) *sessionStore {
+	return &sessionStore{
+		ttl:      ttl,
+		sessions: make(map[string]storedSession),
+	}
+}
+
+// Open сохраняет сессию открытого стрима, detach завершает этот стрим
+func (st *sessionStore) Open(session *streamSession, detach func()) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.prune(time.Now())
+	st.sessions[session.token] = storedSession{session: session, detach: detach}
+}
+
+// Put сохраняет на ttl сессию завершившегося стрима, если ее еще не забрал другой стрим
+func (st *sessionStore) Put(session *streamSession) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	now := time.Now()
+	st.prune(now)
+
+	if stored, found := st.sessions[session.token]; !found || stored.session != session {
+		return
+	}
+	st.sessions[session.token] = storedSession{session: session, expires: now.Add(st.ttl)}
+}
+
+// Take забирает сессию клиента principal по токену, если она принадлежит открытому стриму - завершает его
+// и забирает копию сессии. Пока сессию не сохранят Open, возобновить ее другим стримом нельзя
+func (st *sessionStore) Take(token, principal string) (*streamSession, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.prune(time.Now())
+
+	stored, found := st.sessions[token]
+	if !found || stored.session.principal != principal {
+		return nil, false
+	}
+	delete(st.sessions, token)
+
+	if stored.detach != nil {
+		stored.detach()
+		return stored.session.snapshot(), true
+	}
+	return stored.session, true
+}
+
+// prune удаляет сессии завершившихся стримов, ttl которых истек
+func (st *sessionStore) prune(now time.Time) {
+	for token, stored := range st.sessions {
+		if stored.detach == nil && now.After(stored.expires) {
+			delete(st.sessions, token)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+type WorkerState int
+
+const (
+	WorkerRunning WorkerState = iota
+	WorkerBackingOff
+	WorkerFailed
+)
+
+func (s WorkerState) String() string {
+	switch s {
+	case WorkerRunning:
+		return "running"
+	case WorkerBackingOff:
+		return "backing off"
+	case WorkerFailed:
+		return "failed"
+	}
+	return "unknown"
+}
+
+type WorkerStatus struct {
+	State    WorkerState
+	Restarts uint
+	LastErr  error
+}
+
+// work должна возвращать nil только при закрытии abort, а polled вызывать после каждого успешного опроса,
+// чтобы супервизор сбрасывал счетчик неудачных перезапусков
+type workerFunc func(abort <-chan struct{}, polled func()) error
+
+// supervisor перезапускает упавших воркеров с экспоненциальной задержкой и джиттером
+// и хранит состояние воркера каждого спорта
+type supervisor struct {
+	backoffInitial time.Duration
+	backoffMax     time.Duration
+	maxRestarts    uint // 0 - перезапускать бесконечно
+
+	// ожидание задержки и джиттер, подменяются в тестах
+	after  func(d time.Duration) <-chan time.Time
+	jitter func(n int64) int64
+
+	abort <-chan struct{}
+	n     sync.WaitGroup
+
+	mu       sync.RWMutex
+	statuses map[string]*WorkerStatus
+}
+
+func newSupervisor(backoffInitial, backoffMax time.Duration, maxRestarts uint, abort <-chan struct{}) *supervisor {
+	return &supervisor{
+		backoffInitial: backoffInitial,
+		backoffMax:     backoffMax,
+		maxRestarts:    maxRestarts,
+		after:          time.After,
+		jitter:         rand.Int63n,
+		abort:          abort,
+		statuses:       make(map[string]*WorkerStatus),
+	}
+}
+
+func (s *supervisor) Start(sportName string, work workerFunc) {
+	s.mu.Lock()
+	s.statuses[sportName] = &WorkerStatus{State: WorkerRunning}
+	s.mu.Unlock()
+
+	s.n.Add(1)
+	go s.run(sportName, work)
+}
+
+func (s *supervisor) run(sportName string, work workerFunc) {
+	defer s.n.Done()
+
+	// кол-во неудачных перезапусков подряд
+	var attempt uint
+	polled := func() {
+		s.mu.Lock()
+		attempt = 0
+		s.statuses[sportName].State = WorkerRunning
+		s.mu.Unlock()
+	}
+
+	for {
+		err := work(s.abort, polled)
+		if err == nil {
+			return
+		}
+
+		s.mu.Lock()
+		status := s.statuses[sportName]
+		status.LastErr = err
+		if s.maxRestarts != 0 && attempt >= s.maxRestarts {
+			status.State = WorkerFailed
+			s.mu.Unlock()
+			log.Printf("The %v worker failed %d time(s) in a row, giving up: %v\n", sportName, attempt+1, err)
+			return
+		}
+		status.State = WorkerBackingOff
+		status.Restarts++
+		delay := s.backoff(attempt)
+		attempt++
+		s.mu.Unlock()
+
+		log.Printf("The %v worker failed, restarting in %v: %v\n", sportName, delay, err)
+
+		select {
+		case <-s.abort:
+			return
+		case <-s.after(delay):
+		}
+
+		s.mu.Lock()
+		status.State = WorkerRunning
+		s.mu.Unlock()
+	}
+}
+
+// backoff возвращает задержку в диапазоне [d/2, d], где d = backoffInitial * 2^attempt, но не больше backoffMax
+func (s *supervisor) backoff(attempt uint) time.Duration {
+	d := s.backoffMax
+	if attempt < 32 && s.backoffInitial<<attempt < s.backoffMax {
+		d = s.backoffInitial << attempt
+	}
+	return d/2 + time.Duration(s.jitter(int64(d/2)+1))
+}
+
+func (s *supervisor) Status(sportName string) (WorkerStatus, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	status, found := s.statuses[sportName]
+	if !found {
+		return WorkerStatus{}, false
+	}
+	return *status, true
+}
+
+func (s *supervisor) Statuses() map[string]WorkerStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statuses := make(map[string]WorkerStatus, len(s.statuses))
+	for name, status := range s.statuses {
+		statuses[name] = *status
+	}
+	return statuses
+}
+
+func (s *supervisor) Wait() {
+	s.n.Wait()
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestSupervisor возвращает супервизор, который не ждет задержек, а записывает их в delays,
+// и с джиттером, всегда дающим максимальную задержку
+func newTestSupervisor(maxRestarts uint, delays *[]time.Duration) *supervisor {
+	sv := newSupervisor(time.Second, 10*time.Second, maxRestarts, make(chan struct{}))
+	sv.after = func(d time.Duration) <-chan time.Time {
+		*delays = append(*delays, d)
+		c := make(chan time.Time, 1)
+		c <- time.Now()
+		return c
+	}
+	sv.jitter = func(n int64) int64 { return n - 1 }
+	return sv
+}
+
+func TestSupervisorBackoff(t *testing.T) {
+	sv := newSupervisor(time.Second, 10*time.Second, 0, nil)
+
+	tests := []struct {
+		attempt uint
+		d       time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{3, 8 * time.Second},
+		{4, 10 * time.Second}, // 16s обрезается до backoffMax
+		{40, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		sv.jitter = func(n int64) int64 { return 0 }
+		if delay := sv.backoff(tt.attempt); delay != tt.d/2 {
+			t.Fatalf("Attempt %d: expected the min delay %v, got %v", tt.attempt, tt.d/2, delay)
+		}
+
+		sv.jitter = func(n int64) int64 { return n - 1 }
+		if delay := sv.backoff(tt.attempt); delay != tt.d {
+			t.Fatalf("Attempt %d: expected the max delay %v, got %v", tt.attempt, tt.d, delay)
+		}
+	}
+}
+
+func TestSupervisorGivesUpAfterMaxRestarts(t *testing.T) {
+	var delays []time.Duration
+	sv := newTestSupervisor(2, &delays)
+
+	var calls int
+	sv.Start("soccer", func(abort <-chan struct{}, polled func()) error {
+		calls++
+		return errors.New("provider is down")
+	})
+	sv.Wait()
+
+	if calls != 3 {
+		t.Fatalf("Expected the worker to run 3 times, ran %d", calls)
+	}
+	if want := []time.Duration{time.Second, 2 * time.Second}; !reflect.DeepEqual(delays, want) {
+		t.Fatalf("Expected delays %v, got %v", want, delays)
+	}
+
+	status, _ := sv.Status("soccer")
+	if status.State != WorkerFailed || status.Restarts != 2 || status.LastErr == nil {
+		t.Fatalf("Unexpected status: %+v", status)
+	}
+}
+
+func TestSupervisorPolledResetsAttempts(t *testing.T) {
+	var delays []time.Duration
+	sv := newTestSupervisor(2, &delays)
+
+	var calls int
+	sv.Start("soccer", func(abort <-chan struct{}, polled func()) error {
+		calls++
+		// третий запуск успешно опрашивает провайдера перед падением
+		if calls == 3 {
+			polled()
+		}
+		return errors.New("provider is down")
+	})
+	sv.Wait()
+
+	// после успешного опроса задержка снова начальная, и до отказа есть еще maxRestarts перезапусков
+	if calls != 5 {
+		t.Fatalf("Expected the worker to run 5 times, ran %d", calls)
+	}
+	if want := []time.Duration{time.Second, 2 * time.Second, time.Second, 2 * time.Second}; !reflect.DeepEqual(delays, want) {
+		t.Fatalf("Expected delays %v, got %v", want, delays)
+	}
+
+	status, _ := sv.Status("soccer")
+	if status.State != WorkerFailed || status.Restarts != 4 {
+		t.Fatalf("Unexpected status: %+v", status)
+	}
+}